@@ -2,10 +2,14 @@ package simpler_world
 
 import (
 	"fmt"
-	"image/jpeg"
+	"log"
 	"math"
+	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/schollz/progressbar/v3"
+	"happymonday.dev/ray-tracer/src/environment"
 	"happymonday.dev/ray-tracer/src/lights"
 	"happymonday.dev/ray-tracer/src/matrix"
 	"happymonday.dev/ray-tracer/src/shapes"
@@ -25,7 +29,7 @@ func worldOne() *world.World {
 	s2.Material().Diffuse = 0.7
 	s2.Material().Specular = 0.2
 	s2.SetTransform(matrix.Scaling(0.5, 0.5, 0.5).Multiply(matrix.Translation(5, 0, 0)))
-	return &world.World{Objects: []shapes.Shape{s1, s2}, Lights: []*lights.PointLight{l}}
+	return &world.World{Objects: []shapes.Shape{s1, s2}, Lights: []lights.Light{l}}
 }
 
 func worldOneBacked() *world.World {
@@ -59,12 +63,21 @@ func worldOneBacked() *world.World {
 	sbz.Material().Specular = 0.2
 	sbz.SetTransform(matrix.Chain(matrix.Scaling(100, 100, 0.01), matrix.Translation(0, 0, -10)))
 
-	return &world.World{Objects: []shapes.Shape{s1, s2, sbx, sby, sbz}, Lights: []*lights.PointLight{l}}
+	return &world.World{Objects: []shapes.Shape{s1, s2, sbx, sby, sbz}, Lights: []lights.Light{l}}
 }
 
 func worldFull() *world.World {
-	l1 := lights.InitPointLight(tuples.InitPoint(-10, 10, -10), viz.InitColor(1, 1, 1))
-	l2 := lights.InitPointLight(tuples.InitPoint(-9.5, 10, -10), viz.InitColor(1, 1, 1))
+	// A square light as bright as the pair of point lights this used to fake
+	// soft shadows with.
+	l, err := lights.InitAreaLight(
+		tuples.InitPoint(-10.5, 10, -10.5),
+		tuples.InitVector(1, 0, 0), 4,
+		tuples.InitVector(0, 0, 1), 4,
+		viz.InitColor(2, 2, 2),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
 	s1 := shapes.InitSphere()
 	s1.Material().Color = viz.InitColor(0.8, 1.0, 0.6)
 	s1.Material().Diffuse = 0.7
@@ -100,7 +113,7 @@ func worldFull() *world.World {
 	sbz.Material().Specular = 0.2
 	sbz.SetTransform(matrix.Chain(matrix.Scaling(100, 100, 0.01), matrix.Translation(0, 0, 10)))
 
-	return &world.World{Objects: []shapes.Shape{s1, s2, s3, sbx, sby, sbz}, Lights: []*lights.PointLight{l1, l2}}
+	return &world.World{Objects: []shapes.Shape{s1, s2, s3, sbx, sby, sbz}, Lights: []lights.Light{l}}
 }
 
 func worldHeart() *world.World {
@@ -145,7 +158,7 @@ func worldHeart() *world.World {
 	sbz.Material().Specular = 0.2
 	sbz.SetTransform(matrix.Chain(matrix.Scaling(100, 100, 0.01), matrix.Translation(0, 0, 10)))
 
-	return &world.World{Objects: []shapes.Shape{s1, s2, sbx, sby, sbz}, Lights: []*lights.PointLight{l}}
+	return &world.World{Objects: []shapes.Shape{s1, s2, sbx, sby, sbz}, Lights: []lights.Light{l}}
 }
 
 func worldBook() *world.World {
@@ -208,10 +221,32 @@ func worldBook() *world.World {
 			matrix.Translation(-1.5, 0.33, -0.75),
 		))
 
-	return &world.World{Objects: []shapes.Shape{floor, left_wall, right_wall, middle, left, right}, Lights: []*lights.PointLight{l}}
+	return &world.World{Objects: []shapes.Shape{floor, left_wall, right_wall, middle, left, right}, Lights: []lights.Light{l}}
+}
+
+// worldStage lights the book scene with colored spot lights instead of a
+// single bulb.
+func worldStage() *world.World {
+	w := worldBook()
+	w.Lights = []lights.Light{
+		lights.InitSpotLight(tuples.InitPoint(-4, 6, -4), tuples.InitVector(3.5, -5, 4.5), math.Pi/16, math.Pi/10, viz.InitColor(1, 0.6, 0.6)),
+		lights.InitSpotLight(tuples.InitPoint(4, 6, -4), tuples.InitVector(-2.5, -5.5, 3.5), math.Pi/14, math.Pi/8, viz.InitColor(0.6, 0.6, 1)),
+		lights.InitSpotLight(tuples.InitPoint(0, 8, -6), tuples.InitVector(-1.5, -7.7, 5.25), math.Pi/20, math.Pi/12, viz.InitColor(1, 1, 0.8)),
+	}
+	return w
 }
 
 func SimplerWorld(ctx *gin.Context) {
+	format, err := viz.ParseImageFormat(ctx.DefaultQuery("format", string(viz.FormatJPEG)))
+	if err != nil {
+		ctx.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	post, err := postProcess(ctx)
+	if err != nil {
+		ctx.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
 	s := 500
 	c := world.InitCamera(s, s, math.Pi/2.0)
 	from := tuples.InitPoint(0, 0, -5)
@@ -237,15 +272,60 @@ func SimplerWorld(ctx *gin.Context) {
 		c = world.InitCamera(s, s, math.Pi/3.0)
 		from = tuples.InitPoint(0, 1.5, -5)
 		to = tuples.InitPoint(0, 1, 0)
+	case "stage":
+		fmt.Println("Displaying world stage")
+		w = worldStage()
+		c = world.InitCamera(s, s, math.Pi/3.0)
+		from = tuples.InitPoint(0, 1.5, -5)
+		to = tuples.InitPoint(0, 1, 0)
 	default:
 		fmt.Println("Displaying world default")
 		w = world.InitDefaultWorld()
 	}
+	switch ctx.Query("environment") {
+	case "sky":
+		w.Environment = environment.InitGradientSky(viz.InitColor(0.3, 0.5, 0.9), viz.InitColor(0.9, 0.9, 1), viz.InitColor(0.3, 0.25, 0.2))
+		w.EnvironmentSamples = 16
+	case "daylight":
+		sky := environment.InitPreethamSky(tuples.InitVector(-1, 1.5, -1), 3)
+		w.Environment = sky
+		w.Lights = []lights.Light{sky.Sun()}
+	}
 	c.SetTransform(world.ViewTransformation(from, to, up))
-	img := c.Render(w)
-	jpeg.Encode(
-		ctx.Writer,
-		img.DrawRGBA(),
-		nil,
-	)
+	bar := progressbar.Default(int64(len(c.Tiles())))
+	bar.Describe("Rendering")
+	img, err := c.RenderContext(ctx.Request.Context(), w, func(t world.Tile, done, total int) {
+		bar.Add(1)
+	})
+	if err != nil {
+		// the client went away or the server is shutting down, either way
+		// there's no image to send
+		ctx.AbortWithError(http.StatusServiceUnavailable, err)
+		return
+	}
+	ctx.Header("Content-Type", format.ContentType())
+	if err := viz.EncodeCanvasWith(ctx.Writer, img, format, post); err != nil {
+		ctx.Error(err)
+	}
+}
+
+// postProcess reads the tonemap, exposure (in stops) and srgb query
+// parameters.
+func postProcess(ctx *gin.Context) (viz.PostProcess, error) {
+	p := viz.PostProcess{}
+	var err error
+	if p.ToneMap, err = viz.ParseToneMap(ctx.Query("tonemap")); err != nil {
+		return p, err
+	}
+	if e := ctx.Query("exposure"); e != "" {
+		if p.Exposure, err = strconv.ParseFloat(e, 64); err != nil {
+			return p, fmt.Errorf("invalid exposure %q", e)
+		}
+	}
+	if s := ctx.Query("srgb"); s != "" {
+		if p.SRGB, err = strconv.ParseBool(s); err != nil {
+			return p, fmt.Errorf("invalid srgb %q", s)
+		}
+	}
+	return p, nil
 }
@@ -3,7 +3,6 @@ package three_d_ray_cast
 import (
 	"image"
 	"image/color"
-	"image/jpeg"
 	"net/http"
 	"sync"
 
@@ -81,7 +80,7 @@ func (c *BasicCast) HitColor(source *tuples.Tuple, x, y int) *viz.Color {
 	point := r.Position(h.T)
 	normal := c.Sphere.NormalAt(point)
 	eye := r.Direction.Negate()
-	return c.Light.Lighting(c.Sphere.Material(), point, eye, normal, false)
+	return c.Light.Lighting(c.Sphere.Material(), c.Sphere, point, eye, normal, 1)
 }
 
 func (c *BasicCast) Height() int {
@@ -155,6 +154,11 @@ func ThreeDRayCastLightMoves(c *gin.Context) {
 }
 
 func ThreeDRayCastLightJpeg(c *gin.Context) {
+	format, err := viz.ParseImageFormat(c.DefaultQuery("format", string(viz.FormatJPEG)))
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
 	size := 500.0
 	s := shapes.InitSphere()
 	s.Material().Color = viz.InitColor(1, 0.2, 1)
@@ -168,9 +172,8 @@ func ThreeDRayCastLightJpeg(c *gin.Context) {
 	light := lights.InitPointLight(tuples.InitPoint(-size/5, size/2, -size/5), lightColor)
 	rc := Init(int(size), int(size), viz.InitColor(255, 255, 0), s, light)
 	img := rc.DrawRGBA(tuples.InitPoint(size/3, size/2, -size/4))
-	jpeg.Encode(
-		c.Writer,
-		img,
-		nil,
-	)
+	c.Header("Content-Type", format.ContentType())
+	if err := viz.EncodeImage(c.Writer, img, format); err != nil {
+		c.Error(err)
+	}
 }
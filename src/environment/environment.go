@@ -0,0 +1,27 @@
+// Package environment provides backgrounds for rays that leave the scene,
+// which can also light it.
+package environment
+
+import (
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// Environment is the light arriving from infinitely far away in each
+// direction.
+type Environment interface {
+	ColorAt(direction *tuples.Tuple) *viz.Color
+}
+
+// Uniform is the same color in every direction.
+type Uniform struct {
+	Color *viz.Color
+}
+
+func InitUniform(c *viz.Color) *Uniform {
+	return &Uniform{c}
+}
+
+func (u *Uniform) ColorAt(direction *tuples.Tuple) *viz.Color {
+	return u.Color
+}
@@ -0,0 +1,17 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+func TestAUniformEnvironmentIsTheSameEverywhere(t *testing.T) {
+	c := viz.InitColor(0.2, 0.4, 0.6)
+	e := InitUniform(c)
+	for _, d := range []*tuples.Tuple{tuples.InitVector(0, 1, 0), tuples.InitVector(1, -2, 3)} {
+		assert.True(t, c.Equals(e.ColorAt(d)))
+	}
+}
@@ -0,0 +1,33 @@
+package environment
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// GradientSky fades from Horizon to Zenith going up and from Horizon to
+// Ground going down.
+type GradientSky struct {
+	Zenith  *viz.Color
+	Horizon *viz.Color
+	Ground  *viz.Color
+}
+
+func InitGradientSky(zenith, horizon, ground *viz.Color) *GradientSky {
+	return &GradientSky{zenith, horizon, ground}
+}
+
+func (g *GradientSky) ColorAt(direction *tuples.Tuple) *viz.Color {
+	y := direction.Normalize().Y
+	if y >= 0 {
+		// Most of the change happens near the horizon, as in a real sky.
+		return lerpColor(g.Horizon, g.Zenith, math.Sqrt(y))
+	}
+	return lerpColor(g.Horizon, g.Ground, math.Sqrt(-y))
+}
+
+func lerpColor(a, b *viz.Color, t float64) *viz.Color {
+	return a.Add(b.Subtract(a).MultiplyScalar(t))
+}
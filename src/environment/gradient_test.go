@@ -0,0 +1,28 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+func TestGradientSky(t *testing.T) {
+	type opt struct {
+		s   string
+		d   *tuples.Tuple
+		exp *viz.Color
+	}
+	opts := []opt{
+		{"straight up", tuples.InitVector(0, 5, 0), viz.InitColor(0, 0, 1)},
+		{"horizon", tuples.InitVector(1, 0, 1), viz.InitColor(1, 1, 1)},
+		{"straight down", tuples.InitVector(0, -1, 0), viz.InitColor(0, 1, 0)},
+		{"a quarter up", tuples.InitVector(0.96825, 0.25, 0), viz.InitColor(0.5, 0.5, 1)},
+		{"a quarter down", tuples.InitVector(0.96825, -0.25, 0), viz.InitColor(0.5, 1, 0.5)},
+	}
+	g := InitGradientSky(viz.InitColor(0, 0, 1), viz.InitColor(1, 1, 1), viz.InitColor(0, 1, 0))
+	for _, o := range opts {
+		assert.True(t, o.exp.Equals(g.ColorAt(o.d)), "%s: %v", o.s, g.ColorAt(o.d))
+	}
+}
@@ -0,0 +1,74 @@
+package environment
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"math"
+	"os"
+
+	"happymonday.dev/ray-tracer/src/patterns"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// LatLong is an equirectangular (latitude-longitude) environment map. The top
+// row of the image is straight up, the bottom straight down, and the middle
+// column looks along +z.
+type LatLong struct {
+	Texture *patterns.ImageTexture
+}
+
+// InitLatLong wraps a canvas, erroring like any image texture if it's empty.
+func InitLatLong(c *viz.Canvas) (*LatLong, error) {
+	tex, err := patterns.InitImageTexture(c)
+	if err != nil {
+		return nil, err
+	}
+	tex.Filter = patterns.FilterBilinear
+	return &LatLong{tex}, nil
+}
+
+// DecodeLatLong reads a Radiance HDR, PFM, or any format the image package
+// decodes. Only HDR and PFM keep brightness above 1, which is what makes an
+// environment useful as a light.
+func DecodeLatLong(r io.Reader) (*LatLong, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(2)
+	var c *viz.Canvas
+	var err error
+	switch {
+	case bytes.Equal(magic, []byte("#?")):
+		c, err = viz.ReadHDR(br)
+	case bytes.Equal(magic, []byte("PF")), bytes.Equal(magic, []byte("Pf")):
+		c, err = viz.ReadPFM(br)
+	default:
+		var tex *patterns.ImageTexture
+		if tex, err = patterns.DecodeImageTexture(br); err == nil {
+			c = tex.Canvas
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return InitLatLong(c)
+}
+
+func LoadLatLong(path string) (*LatLong, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return DecodeLatLong(f)
+}
+
+func (l *LatLong) ColorAt(direction *tuples.Tuple) *viz.Color {
+	d := direction.Normalize()
+	u, v := patterns.SphericalMap(tuples.InitPoint(d.X, d.Y, d.Z))
+	// The map wraps around horizontally but not over the poles, so keep v
+	// between the centers of the top and bottom rows.
+	half := 0.5 / float64(l.Texture.Canvas.Height)
+	v = math.Max(half, math.Min(1-half, v))
+	return l.Texture.UVColorAt(u, v)
+}
@@ -0,0 +1,92 @@
+package environment
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/patterns"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// testMap is 4x2 with the sky bright and each quarter of the ground a
+// different color.
+func testMap() *viz.Canvas {
+	c := viz.InitCanvas(4, 2)
+	for x := 0; x < 4; x++ {
+		c.SetPixel(viz.InitColor(10, 10, 10), x, 0)
+	}
+	c.SetPixel(viz.InitColor(1, 0, 0), 0, 1)
+	c.SetPixel(viz.InitColor(0, 1, 0), 1, 1)
+	c.SetPixel(viz.InitColor(0, 0, 1), 2, 1)
+	c.SetPixel(viz.InitColor(1, 1, 0), 3, 1)
+	return &c
+}
+
+func TestLatLongDirections(t *testing.T) {
+	type opt struct {
+		s   string
+		d   *tuples.Tuple
+		exp *viz.Color
+	}
+	opts := []opt{
+		{"up", tuples.InitVector(0, 1, 0), viz.InitColor(10, 10, 10)},
+		{"down towards -z", tuples.InitVector(0, -1, -0.01), viz.InitColor(1, 0, 0)},
+		{"down towards +x", tuples.InitVector(1, -1, 0), viz.InitColor(0, 1, 0)},
+		{"down towards +z", tuples.InitVector(0, -1, 1), viz.InitColor(0, 0, 1)},
+		{"down towards -x", tuples.InitVector(-1, -1, 0), viz.InitColor(1, 1, 0)},
+	}
+	l, _ := InitLatLong(testMap())
+	l.Texture.Filter = patterns.FilterNearest
+	for _, o := range opts {
+		assert.True(t, o.exp.Equals(l.ColorAt(o.d)), "%s: %v", o.s, l.ColorAt(o.d))
+	}
+}
+
+func TestLatLongMapsAreFilteredBilinearly(t *testing.T) {
+	l, _ := InitLatLong(testMap())
+	// On the horizon half way between sky and ground.
+	c := l.ColorAt(tuples.InitVector(0, 0, 1))
+	assert.True(t, viz.InitColor(5, 5.25, 5.25).Equals(c), "%v", c)
+}
+
+func TestDecodingHighDynamicRangeLatLongMaps(t *testing.T) {
+	encoders := map[string]func(*bytes.Buffer, *viz.Canvas) error{
+		"hdr": func(b *bytes.Buffer, c *viz.Canvas) error { return viz.WriteHDR(b, c) },
+		"pfm": func(b *bytes.Buffer, c *viz.Canvas) error { return viz.WritePFM(b, c) },
+	}
+	for name, encode := range encoders {
+		buf := bytes.Buffer{}
+		assert.Nil(t, encode(&buf, testMap()))
+		l, err := DecodeLatLong(&buf)
+		assert.Nil(t, err, name)
+		assert.True(t, viz.InitColor(10, 10, 10).Equals(l.ColorAt(tuples.InitVector(0, 1, 0))), name)
+	}
+}
+
+func TestDecodingALatLongMapFromAPNG(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.RGBA{0xff, 0, 0, 0xff})
+	img.Set(1, 0, color.RGBA{0xff, 0, 0, 0xff})
+	buf := bytes.Buffer{}
+	assert.Nil(t, png.Encode(&buf, img))
+	l, err := DecodeLatLong(&buf)
+	assert.Nil(t, err)
+	assert.True(t, viz.InitColor(1, 0, 0).Equals(l.ColorAt(tuples.InitVector(0, 1, 0))))
+}
+
+func TestDecodingAnInvalidLatLongMap(t *testing.T) {
+	for _, s := range []string{"#?RADIANCE\n", "#?RADIANCE\n\n-Y 0 +X 0\n", "PF\n0 0\n-1\n", "PF\n1", "nonsense"} {
+		_, err := DecodeLatLong(bytes.NewBufferString(s))
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestLoadingAMissingLatLongMap(t *testing.T) {
+	_, err := LoadLatLong("does-not-exist.hdr")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,175 @@
+package environment
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/lights"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// PreethamSky is the analytic daylight model from Preetham, Shirley and
+// Smits, "A Practical Analytic Model for Daylight" (1999). Turbidity is how
+// hazy the air is, from 2 for a clear day to 10 for a very hazy one. The
+// model's fit only holds in that range, outside it goes negative or brighter
+// than the sun, so other values are clamped to it. Directions below the
+// horizon see the sky at the horizon.
+type PreethamSky struct {
+	// SunDirection points from the scene towards the sun, straight up when
+	// it's zero.
+	SunDirection *tuples.Tuple
+	Turbidity    float64
+	// Scale converts the model's luminance, in thousands of cd/m^2, into
+	// scene colors.
+	Scale float64
+	// SunIntensity is the brightness of the light from Sun before the
+	// atmosphere absorbs some of it.
+	SunIntensity float64
+}
+
+func InitPreethamSky(sunDirection *tuples.Tuple, turbidity float64) *PreethamSky {
+	return &PreethamSky{
+		SunDirection: normalizeSun(sunDirection),
+		Turbidity:    turbidity,
+		Scale:        0.05,
+		SunIntensity: 1,
+	}
+}
+
+// normalizeSun normalizes d, taking a zero direction, which has none, as
+// straight up.
+func normalizeSun(d *tuples.Tuple) *tuples.Tuple {
+	if d.Magnitude() == 0 {
+		return tuples.InitVector(0, 1, 0)
+	}
+	return d.Normalize()
+}
+
+func (s *PreethamSky) sunDirection() *tuples.Tuple {
+	return normalizeSun(s.SunDirection)
+}
+
+// turbidity is Turbidity clamped to the range the model was fitted to.
+func (s *PreethamSky) turbidity() float64 {
+	return math.Max(2, math.Min(10, s.Turbidity))
+}
+
+// perez is the sky's distribution relative to the zenith, with coefficients
+// for one of Y, x or y. theta is the view's angle from the zenith and gamma
+// its angle from the sun.
+type perez [5]float64
+
+func (p perez) f(cosTheta, gamma float64) float64 {
+	a, b, c, d, e := p[0], p[1], p[2], p[3], p[4]
+	cosGamma := math.Cos(gamma)
+	return (1 + a*math.Exp(b/cosTheta)) * (1 + c*math.Exp(d*gamma) + e*cosGamma*cosGamma)
+}
+
+func (s *PreethamSky) coefficients() (perez, perez, perez) {
+	t := s.turbidity()
+	return perez{0.1787*t - 1.4630, -0.3554*t + 0.4275, -0.0227*t + 5.3251, 0.1206*t - 2.5771, -0.0670*t + 0.3703},
+		perez{-0.0193*t - 0.2592, -0.0665*t + 0.0008, -0.0004*t + 0.2125, -0.0641*t - 0.8989, -0.0033*t + 0.0452},
+		perez{-0.0167*t - 0.2608, -0.0950*t + 0.0092, -0.0079*t + 0.2102, -0.0441*t - 1.6537, -0.0109*t + 0.0529}
+}
+
+// zenith is the luminance Y and chromaticity x, y straight up.
+func (s *PreethamSky) zenith() (float64, float64, float64) {
+	t := s.turbidity()
+	ts := s.sunTheta()
+	chi := (4.0/9.0 - t/120) * (math.Pi - 2*ts)
+	yz := (4.0453*t-4.9710)*math.Tan(chi) - 0.2155*t + 2.4192
+
+	ts2, ts3 := ts*ts, ts*ts*ts
+	xz := t*t*(0.00166*ts3-0.00375*ts2+0.00209*ts) +
+		t*(-0.02903*ts3+0.06377*ts2-0.03202*ts+0.00394) +
+		(0.11693*ts3 - 0.21196*ts2 + 0.06052*ts + 0.25886)
+	yc := t*t*(0.00275*ts3-0.00610*ts2+0.00317*ts) +
+		t*(-0.04214*ts3+0.08970*ts2-0.04153*ts+0.00516) +
+		(0.15346*ts3 - 0.26756*ts2 + 0.06670*ts + 0.26688)
+	return yz, xz, yc
+}
+
+// sunTheta is the sun's angle from the zenith, kept above the horizon where
+// the model holds.
+func (s *PreethamSky) sunTheta() float64 {
+	return math.Acos(math.Max(s.sunDirection().Y, 0))
+}
+
+// horizonY is how far above the horizon directions are kept.
+const horizonY = 0.001
+
+// yxy is the luminance and chromaticity seen looking along direction.
+func (s *PreethamSky) yxy(direction *tuples.Tuple) (float64, float64, float64) {
+	d := direction.Normalize()
+	// Stay just above the horizon, where 1 / cos(theta) blows up.
+	if d.Y < horizonY {
+		d = toHorizon(d, horizonY)
+	}
+	cosTheta := d.Y
+	// A set sun lights the sky from where it went down.
+	sun := s.sunDirection()
+	if sun.Y < 0 {
+		sun = toHorizon(sun, 0)
+	}
+	gamma := math.Acos(math.Max(-1, math.Min(1, d.DotProduct(sun))))
+	ts := s.sunTheta()
+
+	pY, px, py := s.coefficients()
+	zY, zx, zy := s.zenith()
+	relative := func(p perez) float64 {
+		return p.f(cosTheta, gamma) / p.f(1, ts)
+	}
+	return zY * relative(pY), zx * relative(px), zy * relative(py)
+}
+
+// toHorizon moves d to height y keeping its compass direction, or looking
+// along +x for straight up or down which have none.
+func toHorizon(d *tuples.Tuple, y float64) *tuples.Tuple {
+	if d.X == 0 && d.Z == 0 {
+		d = tuples.InitVector(1, 0, 0)
+	}
+	d = tuples.InitVector(d.X, 0, d.Z).Normalize()
+	d.Y = y
+	return d
+}
+
+func (s *PreethamSky) ColorAt(direction *tuples.Tuple) *viz.Color {
+	Y, x, y := s.yxy(direction)
+	return xyzToRGB(x/y*Y, Y, (1-x-y)/y*Y).MultiplyScalar(s.Scale)
+}
+
+// xyzToRGB converts CIE XYZ to linear sRGB primaries.
+func xyzToRGB(x, y, z float64) *viz.Color {
+	return viz.InitColor(
+		3.2406*x-1.5372*y-0.4986*z,
+		-0.9689*x+1.8758*y+0.0415*z,
+		0.0557*x-0.2040*y+1.0570*z,
+	)
+}
+
+// Sun is a directional light shining from SunDirection, colored by how much
+// of each of red, green and blue survives the trip through the atmosphere.
+// It's black once the sun has set.
+func (s *PreethamSky) Sun() *lights.DirectionalLight {
+	return lights.InitDirectionalLight(s.sunDirection().Negate(), s.SunColor())
+}
+
+// SunColor uses the Rayleigh and aerosol (Angstrom) transmittance terms from
+// the paper at representative red, green and blue wavelengths.
+func (s *PreethamSky) SunColor() *viz.Color {
+	if s.sunDirection().Y <= 0 {
+		return viz.Black()
+	}
+	thetaDeg := s.sunTheta() * 180 / math.Pi
+	// Relative optical air mass, how much more air the light passes through
+	// than when the sun is overhead.
+	m := 1 / (math.Cos(s.sunTheta()) + 0.15*math.Pow(93.885-thetaDeg, -1.253))
+	beta := 0.04608*s.turbidity() - 0.04586
+	rgb := [3]float64{}
+	for i, lambda := range []float64{0.65, 0.57, 0.475} {
+		rayleigh := math.Exp(-0.008735 * math.Pow(lambda, -4.08) * m)
+		aerosol := math.Exp(-beta * math.Pow(lambda, -1.3) * m)
+		rgb[i] = s.SunIntensity * rayleigh * aerosol
+	}
+	return viz.InitColor(rgb[0], rgb[1], rgb[2])
+}
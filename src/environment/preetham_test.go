@@ -0,0 +1,158 @@
+package environment
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// elevated is a direction towards +z raised by degrees above the horizon.
+func elevated(degrees float64) *tuples.Tuple {
+	r := degrees * math.Pi / 180
+	return tuples.InitVector(0, math.Sin(r), math.Cos(r))
+}
+
+func TestPreethamZenithMatchesThePaper(t *testing.T) {
+	s := InitPreethamSky(elevated(45), 3)
+	// chi = (4/9 - 3/120)(pi - pi/2)
+	chi := (4.0/9.0 - 0.025) * math.Pi / 2
+	expY := (4.0453*3-4.9710)*math.Tan(chi) - 0.2155*3 + 2.4192
+	Y, x, y := s.yxy(tuples.InitVector(0, 1, 0))
+	assert.True(t, maths.FuzzyEquals(expY, Y), "%v", Y)
+	zY, zx, zy := s.zenith()
+	assert.True(t, maths.FuzzyEquals(zY, Y))
+	assert.True(t, maths.FuzzyEquals(zx, x))
+	assert.True(t, maths.FuzzyEquals(zy, y))
+	// A clear sky's zenith is bluer than white, which is about (0.31, 0.33).
+	assert.Less(t, x, 0.31)
+	assert.Less(t, y, 0.33)
+}
+
+func TestPreethamSkyIsBrightestAroundTheSun(t *testing.T) {
+	sun := elevated(30)
+	s := InitPreethamSky(sun, 2.5)
+	towards := s.ColorAt(sun)
+	away := s.ColorAt(tuples.InitVector(0, sun.Y, -sun.Z))
+	for _, v := range [][2]float64{{towards.R(), away.R()}, {towards.G(), away.G()}, {towards.B(), away.B()}} {
+		assert.Greater(t, v[0], v[1])
+	}
+}
+
+func TestAClearPreethamSkyIsBlue(t *testing.T) {
+	s := InitPreethamSky(elevated(40), 2)
+	c := s.ColorAt(tuples.InitVector(0, 1, 0))
+	assert.Greater(t, c.B(), c.R())
+	assert.Greater(t, c.R(), 0.0)
+}
+
+func TestHazierPreethamSkiesAreWhiter(t *testing.T) {
+	blueness := func(turbidity float64) float64 {
+		c := InitPreethamSky(elevated(40), turbidity).ColorAt(tuples.InitVector(0, 1, 0))
+		return c.B() / c.R()
+	}
+	assert.Greater(t, blueness(2), blueness(6))
+	assert.Greater(t, blueness(6), blueness(10))
+}
+
+func TestPreethamSkyBelowTheHorizonIsTheHorizon(t *testing.T) {
+	s := InitPreethamSky(elevated(20), 3)
+	horizon := s.ColorAt(tuples.InitVector(1, 0, 0))
+	assert.True(t, horizon.Equals(s.ColorAt(tuples.InitVector(1, -0.5, 0))))
+	assert.True(t, horizon.Equals(s.ColorAt(tuples.InitVector(1, -100, 0))))
+	assert.True(t, s.ColorAt(tuples.InitVector(1, 0, 0)).Equals(s.ColorAt(tuples.InitVector(0, -1, 0))))
+}
+
+func TestAPreethamSunStraightDownSetsOnTheHorizon(t *testing.T) {
+	s := InitPreethamSky(tuples.InitVector(0, -1, 0), 3)
+	set := InitPreethamSky(tuples.InitVector(1, 0, 0), 3)
+	for _, d := range []*tuples.Tuple{
+		tuples.InitVector(0, 1, 0),
+		tuples.InitVector(1, 0.2, 0),
+		tuples.InitVector(-1, 0.5, 1),
+		tuples.InitVector(0, -1, 0),
+	} {
+		c := s.ColorAt(d)
+		for _, v := range []float64{c.R(), c.G(), c.B()} {
+			assert.False(t, math.IsNaN(v) || math.IsInf(v, 0), "%v: %v", d, c)
+		}
+		assert.True(t, set.ColorAt(d).Equals(c), "%v: %v", d, c)
+	}
+	assert.True(t, viz.Black().Equals(s.SunColor()))
+}
+
+func TestThePreethamSunMatchesTheSky(t *testing.T) {
+	sun := elevated(60)
+	s := InitPreethamSky(sun, 3)
+	l := s.Sun()
+	assert.True(t, sun.Negate().Equals(l.Direction))
+
+	high := s.SunColor()
+	s.SunDirection = elevated(5)
+	low := s.SunColor()
+	// The low sun shines through more air, dimming it and scattering away
+	// more blue than red.
+	assert.Less(t, low.G(), high.G())
+	assert.Greater(t, low.R()/low.B(), high.R()/high.B())
+	assert.Greater(t, high.R(), high.B())
+	assert.Less(t, high.R(), 1.0)
+
+	s.SunDirection = elevated(-10)
+	assert.True(t, viz.Black().Equals(s.Sun().Intensity))
+}
+
+func TestHazeDimsThePreethamSun(t *testing.T) {
+	clear := InitPreethamSky(elevated(45), 2).SunColor()
+	hazy := InitPreethamSky(elevated(45), 8).SunColor()
+	assert.Less(t, hazy.G(), clear.G())
+}
+
+func TestPreethamTurbidityIsClampedToTheModelsRange(t *testing.T) {
+	type opt struct {
+		turbidity float64
+		clamped   float64
+	}
+	opts := []opt{
+		{0.5, 2},
+		{1, 2},
+		{12, 10},
+		{50, 10},
+	}
+	dirs := []*tuples.Tuple{
+		tuples.InitVector(0, 1, 0),
+		elevated(30),
+		elevated(0),
+		tuples.InitVector(1, 0.2, 0),
+		tuples.InitVector(0, -1, 0),
+	}
+	for _, o := range opts {
+		s := InitPreethamSky(elevated(20), o.turbidity)
+		exp := InitPreethamSky(elevated(20), o.clamped)
+		for _, d := range dirs {
+			c := s.ColorAt(d)
+			assert.True(t, exp.ColorAt(d).Equals(c), "%v %v: %v", o.turbidity, d, c)
+			for _, v := range []float64{c.R(), c.G(), c.B()} {
+				assert.False(t, math.IsNaN(v) || math.IsInf(v, 0), "%v %v: %v", o.turbidity, d, c)
+				assert.GreaterOrEqual(t, v, 0.0, "%v %v: %v", o.turbidity, d, c)
+			}
+		}
+		sun := s.SunColor()
+		assert.True(t, exp.SunColor().Equals(sun), "%v: %v", o.turbidity, sun)
+		assert.LessOrEqual(t, sun.R(), 1.0, "%v: %v", o.turbidity, sun)
+	}
+}
+
+func TestAZeroPreethamSunDirectionIsStraightUp(t *testing.T) {
+	s := InitPreethamSky(tuples.InitVector(0, 0, 0), 3)
+	up := InitPreethamSky(tuples.InitVector(0, 1, 0), 3)
+	for _, d := range []*tuples.Tuple{tuples.InitVector(0, 1, 0), elevated(30), tuples.InitVector(1, 0, 0)} {
+		assert.True(t, up.ColorAt(d).Equals(s.ColorAt(d)), "%v: %v", d, s.ColorAt(d))
+	}
+	s.SunDirection = tuples.InitVector(0, 0, 0)
+	assert.True(t, up.ColorAt(elevated(30)).Equals(s.ColorAt(elevated(30))))
+	assert.True(t, up.Sun().Direction.Equals(s.Sun().Direction))
+	assert.True(t, up.SunColor().Equals(s.SunColor()))
+}
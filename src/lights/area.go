@@ -0,0 +1,100 @@
+package lights
+
+import (
+	"fmt"
+
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/shapes"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// AreaLight is a rectangle of light divided into USteps by VSteps cells. It's
+// sampled at a point in every cell, so points that can see only part of it
+// are partly lit and shadows get soft edges.
+type AreaLight struct {
+	Corner    *tuples.Tuple
+	UVec      *tuples.Tuple
+	USteps    int
+	VVec      *tuples.Tuple
+	VSteps    int
+	Intensity *viz.Color
+	// Jitter returns where in a cell, from 0 to 1, to take each sample.
+	// Random jitter turns the banding of a regular grid into noise. When nil
+	// the numbers are seeded from the point being lit so renders repeat
+	// exactly. Renders light points from several goroutines at once, so a
+	// Jitter must be safe for concurrent use, which a *rand.Rand isn't.
+	Jitter func() float64
+}
+
+// InitAreaLight makes a light spanning fullUVec and fullVVec from corner. It
+// errors without at least one step in each direction.
+func InitAreaLight(corner, fullUVec *tuples.Tuple, usteps int, fullVVec *tuples.Tuple, vsteps int, intensity *viz.Color) (*AreaLight, error) {
+	if usteps < 1 || vsteps < 1 {
+		return nil, fmt.Errorf("area light needs at least one step each way, got %d by %d", usteps, vsteps)
+	}
+	return &AreaLight{
+		Corner:    corner,
+		UVec:      fullUVec.MultiplyScalar(1 / float64(usteps)),
+		USteps:    usteps,
+		VVec:      fullVVec.MultiplyScalar(1 / float64(vsteps)),
+		VSteps:    vsteps,
+		Intensity: intensity,
+	}, nil
+}
+
+func (a *AreaLight) Samples() int {
+	return a.USteps * a.VSteps
+}
+
+// Position is the center of the light.
+func (a *AreaLight) Position() *tuples.Tuple {
+	return a.Corner.
+		Add(a.UVec.MultiplyScalar(float64(a.USteps) / 2)).
+		Add(a.VVec.MultiplyScalar(float64(a.VSteps) / 2))
+}
+
+// PointOn is a sample point in cell (u, v), placed by Jitter or in the middle
+// of the cell when Jitter is nil.
+func (a *AreaLight) PointOn(u, v int) *tuples.Tuple {
+	jitter := a.Jitter
+	if jitter == nil {
+		jitter = func() float64 { return 0.5 }
+	}
+	return a.pointOn(u, v, jitter)
+}
+
+func (a *AreaLight) pointOn(u, v int, jitter func() float64) *tuples.Tuple {
+	return a.Corner.
+		Add(a.UVec.MultiplyScalar(float64(u) + jitter())).
+		Add(a.VVec.MultiplyScalar(float64(v) + jitter()))
+}
+
+// samplePoints jitters a point in every cell for lighting point.
+func (a *AreaLight) samplePoints(point *tuples.Tuple) []*tuples.Tuple {
+	jitter := a.Jitter
+	if jitter == nil {
+		jitter = maths.PointJitter(point.X, point.Y, point.Z)
+	}
+	res := make([]*tuples.Tuple, 0, a.Samples())
+	for v := 0; v < a.VSteps; v++ {
+		for u := 0; u < a.USteps; u++ {
+			res = append(res, a.pointOn(u, v, jitter))
+		}
+	}
+	return res
+}
+
+func (a *AreaLight) IntensityAt(point *tuples.Tuple, o Occluder) float64 {
+	lit := 0
+	for _, p := range a.samplePoints(point) {
+		if !o.IsShadowed(p, point) {
+			lit++
+		}
+	}
+	return float64(lit) / float64(a.Samples())
+}
+
+func (a *AreaLight) Lighting(m *shapes.Material, object shapes.Shape, point *tuples.Tuple, eyev *tuples.Tuple, normalv *tuples.Tuple, intensity float64) *viz.Color {
+	return phong(m, object, point, eyev, normalv, a.Intensity, a.samplePoints(point), intensity)
+}
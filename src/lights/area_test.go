@@ -0,0 +1,109 @@
+package lights
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/shapes"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// sequence returns a Jitter that cycles through vs.
+func sequence(vs ...float64) func() float64 {
+	i := 0
+	return func() float64 {
+		v := vs[i%len(vs)]
+		i++
+		return v
+	}
+}
+
+func TestCreatingAnAreaLight(t *testing.T) {
+	l, err := InitAreaLight(tuples.InitPoint(0, 0, 0), tuples.InitVector(2, 0, 0), 4, tuples.InitVector(0, 0, 1), 2, viz.InitColor(1, 1, 1))
+	assert.Nil(t, err)
+	assert.True(t, tuples.InitVector(0.5, 0, 0).Equals(l.UVec))
+	assert.Equal(t, 4, l.USteps)
+	assert.True(t, tuples.InitVector(0, 0, 0.5).Equals(l.VVec))
+	assert.Equal(t, 2, l.VSteps)
+	assert.Equal(t, 8, l.Samples())
+	assert.True(t, tuples.InitPoint(1, 0, 0.5).Equals(l.Position()))
+}
+
+func TestFindingASinglePointOnAnAreaLight(t *testing.T) {
+	type opt struct {
+		u      int
+		v      int
+		jitter func() float64
+		exp    *tuples.Tuple
+	}
+	opts := []opt{
+		{0, 0, sequence(0.5), tuples.InitPoint(0.25, 0, 0.25)},
+		{1, 0, sequence(0.5), tuples.InitPoint(0.75, 0, 0.25)},
+		{0, 1, sequence(0.5), tuples.InitPoint(0.25, 0, 0.75)},
+		{2, 0, sequence(0.5), tuples.InitPoint(1.25, 0, 0.25)},
+		{3, 1, sequence(0.5), tuples.InitPoint(1.75, 0, 0.75)},
+		{0, 0, sequence(0.3, 0.7), tuples.InitPoint(0.15, 0, 0.35)},
+		{1, 0, sequence(0.3, 0.7), tuples.InitPoint(0.65, 0, 0.35)},
+		{3, 1, sequence(0.3, 0.7), tuples.InitPoint(1.65, 0, 0.85)},
+	}
+	for _, o := range opts {
+		l, _ := InitAreaLight(tuples.InitPoint(0, 0, 0), tuples.InitVector(2, 0, 0), 4, tuples.InitVector(0, 0, 1), 2, viz.InitColor(1, 1, 1))
+		l.Jitter = o.jitter
+		assert.True(t, o.exp.Equals(l.PointOn(o.u, o.v)), "%d, %d", o.u, o.v)
+	}
+}
+
+func TestAreaLightIntensityIsTheFractionOfUnblockedSamples(t *testing.T) {
+	l, _ := InitAreaLight(tuples.InitPoint(0, 0, 0), tuples.InitVector(2, 0, 0), 2, tuples.InitVector(0, 0, 2), 2, viz.InitColor(1, 1, 1))
+	l.Jitter = sequence(0.5)
+	// Only samples with x > 1 are blocked.
+	o := occluderFunc(func(lp, p *tuples.Tuple) bool { return lp.X > 1 })
+	assert.Equal(t, 0.5, l.IntensityAt(tuples.InitPoint(0, -5, 0), o))
+}
+
+func TestLightingSamplesTheAreaLight(t *testing.T) {
+	type opt struct {
+		point *tuples.Tuple
+		exp   *viz.Color
+	}
+	opts := []opt{
+		{tuples.InitPoint(0, 0, -1), viz.InitColor(0.9965, 0.9965, 0.9965)},
+		{tuples.InitPoint(0, 0.7071, -0.7071), viz.InitColor(0.62318, 0.62318, 0.62318)},
+	}
+	l, _ := InitAreaLight(tuples.InitPoint(-0.5, -0.5, -5), tuples.InitVector(1, 0, 0), 2, tuples.InitVector(0, 1, 0), 2, viz.InitColor(1, 1, 1))
+	l.Jitter = sequence(0.5)
+	s := shapes.InitSphere()
+	m := s.Material()
+	m.Ambient = 0.1
+	m.Diffuse = 0.9
+	m.Specular = 0
+	m.Color = viz.InitColor(1, 1, 1)
+	eye := tuples.InitPoint(0, 0, -5)
+	for _, o := range opts {
+		eyev := eye.Subtract(o.point).Normalize()
+		normalv := tuples.InitVector(o.point.X, o.point.Y, o.point.Z)
+		c := l.Lighting(m, s, o.point, eyev, normalv, 1)
+		assert.True(t, o.exp.Equals(c), "%v: %v", o.point, c)
+	}
+}
+
+func TestCreatingAnAreaLightWithoutSteps(t *testing.T) {
+	_, err := InitAreaLight(tuples.InitPoint(0, 0, 0), tuples.InitVector(1, 0, 0), 0, tuples.InitVector(0, 0, 1), 1, viz.InitColor(1, 1, 1))
+	assert.NotNil(t, err)
+	_, err = InitAreaLight(tuples.InitPoint(0, 0, 0), tuples.InitVector(1, 0, 0), 1, tuples.InitVector(0, 0, 1), -1, viz.InitColor(1, 1, 1))
+	assert.NotNil(t, err)
+}
+
+func TestAreaLightSamplesRepeatForAPoint(t *testing.T) {
+	l, _ := InitAreaLight(tuples.InitPoint(0, 0, 0), tuples.InitVector(2, 0, 0), 4, tuples.InitVector(0, 0, 2), 4, viz.InitColor(1, 1, 1))
+	o := occluderFunc(func(lp, p *tuples.Tuple) bool { return lp.X+lp.Z > 2 })
+	p := tuples.InitPoint(0.3, -5, 0.2)
+	intensity := l.IntensityAt(p, o)
+	assert.Greater(t, intensity, 0.0)
+	assert.Less(t, intensity, 1.0)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, intensity, l.IntensityAt(p, o))
+	}
+	assert.True(t, tuples.InitPoint(0.25, 0, 0.25).Equals(l.PointOn(0, 0)))
+}
@@ -0,0 +1,36 @@
+package lights
+
+import (
+	"happymonday.dev/ray-tracer/src/shapes"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// DirectionalLight is infinitely far away, like the sun, so its light
+// arrives everywhere from the same direction at the same strength.
+type DirectionalLight struct {
+	// Direction the light travels in.
+	Direction *tuples.Tuple
+	Intensity *viz.Color
+}
+
+func InitDirectionalLight(direction *tuples.Tuple, intensity *viz.Color) *DirectionalLight {
+	return &DirectionalLight{direction.Normalize(), intensity}
+}
+
+// toLight is the vector pointing back at the light, which occluders and
+// phong treat as a light at infinity.
+func (d *DirectionalLight) toLight() *tuples.Tuple {
+	return d.Direction.Negate()
+}
+
+func (d *DirectionalLight) IntensityAt(point *tuples.Tuple, o Occluder) float64 {
+	if o.IsShadowed(d.toLight(), point) {
+		return 0
+	}
+	return 1
+}
+
+func (d *DirectionalLight) Lighting(m *shapes.Material, object shapes.Shape, point *tuples.Tuple, eyev *tuples.Tuple, normalv *tuples.Tuple, intensity float64) *viz.Color {
+	return phong(m, object, point, eyev, normalv, d.Intensity, []*tuples.Tuple{d.toLight()}, intensity)
+}
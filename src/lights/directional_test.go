@@ -0,0 +1,38 @@
+package lights
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/shapes"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+func TestCreatingADirectionalLightNormalizesItsDirection(t *testing.T) {
+	l := InitDirectionalLight(tuples.InitVector(0, -3, 0), viz.InitColor(1, 1, 1))
+	assert.True(t, tuples.InitVector(0, -1, 0).Equals(l.Direction))
+}
+
+func TestDirectionalLightsAskAboutShadowsTowardsTheLight(t *testing.T) {
+	l := InitDirectionalLight(tuples.InitVector(0, -1, 0), viz.InitColor(1, 1, 1))
+	var asked *tuples.Tuple
+	o := occluderFunc(func(lp, p *tuples.Tuple) bool { asked = lp; return false })
+	assert.Equal(t, 1.0, l.IntensityAt(tuples.InitPoint(100, 0, 100), o))
+	assert.True(t, tuples.InitVector(0, 1, 0).Equals(asked))
+}
+
+func TestLightingWithADirectionalLightIsTheSameEverywhere(t *testing.T) {
+	m := shapes.DefaultMaterial()
+	l := InitDirectionalLight(tuples.InitVector(0, -1, 1), viz.InitColor(1, 1, 1))
+	eyev := tuples.InitVector(0, 0, -1)
+	normalv := tuples.InitVector(0, 0, -1)
+	exp := viz.InitColor(0.7364, 0.7364, 0.7364)
+	for _, p := range []*tuples.Tuple{tuples.InitPoint(0, 0, 0), tuples.InitPoint(1000, -50, 20)} {
+		c := l.Lighting(m, shapes.InitSphere(), p, eyev, normalv, 1)
+		assert.True(t, exp.Equals(c), "%v", p)
+	}
+	c := l.Lighting(m, shapes.InitSphere(), tuples.InitPoint(0, 0, 0), eyev, tuples.InitVector(0, math.Sqrt2/2, math.Sqrt2/2), 1)
+	assert.True(t, viz.InitColor(0.1, 0.1, 0.1).Equals(c))
+}
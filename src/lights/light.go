@@ -0,0 +1,73 @@
+package lights
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/shapes"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// Occluder answers whether anything blocks the path between a point on a
+// light and a point being lit, it's implemented by world.World. A light
+// position that's a vector (W = 0) is the direction towards a light
+// infinitely far away.
+type Occluder interface {
+	IsShadowed(lightPosition, point *tuples.Tuple) bool
+}
+
+// Light is anything the world shades hits with. The world asks how much of the
+// light reaches a hit, checking for shadows through the Occluder, then shades
+// the hit with that amount.
+type Light interface {
+	// IntensityAt is the fraction of the light reaching point, from 0 when
+	// it's fully shadowed to 1 when nothing is in the way.
+	IntensityAt(point *tuples.Tuple, o Occluder) float64
+	// Lighting shades point with the diffuse and specular contributions
+	// scaled by intensity, as returned from IntensityAt.
+	Lighting(m *shapes.Material, object shapes.Shape, point, eyev, normalv *tuples.Tuple, intensity float64) *viz.Color
+}
+
+// phong sums the ambient term and the diffuse and specular terms averaged
+// over each of the positions the light is sampled at.
+func phong(m *shapes.Material, object shapes.Shape, point, eyev, normalv *tuples.Tuple, lightColor *viz.Color, positions []*tuples.Tuple, intensity float64) *viz.Color {
+	// combine the surface color with the light's color/intensity
+	effectiveColor := m.ColorAt(object, point).Multiply(lightColor)
+	ambient := effectiveColor.MultiplyScalar(m.Ambient)
+	if intensity == 0 {
+		return ambient
+	}
+	sum := viz.Black()
+	for _, position := range positions {
+		// find the direction of the light source
+		lightv := lightVector(position, point)
+		// lightDotNormal represents the cosine of the angle between the
+		// light vector and the normal vector. A negative number means the light is on the other
+		// side of the surface.
+		lightDotNormal := lightv.DotProduct(normalv)
+		if lightDotNormal < 0 {
+			continue
+		}
+		// compute the diffuse contribution
+		sum = sum.Add(effectiveColor.MultiplyScalar(m.Diffuse * lightDotNormal))
+
+		// reflectDotEye represents the cosine of the angle between the reflection vector
+		// and the eye vector. A negative number means the light reflects away from the eye.
+		reflectv := lightv.Negate().Reflect(normalv)
+		reflectDotEye := reflectv.DotProduct(eyev)
+		if reflectDotEye > 0 {
+			factor := math.Pow(reflectDotEye, m.Shininess)
+			sum = sum.Add(lightColor.MultiplyScalar(m.Specular * factor))
+		}
+	}
+	return ambient.Add(sum.MultiplyScalar(intensity / float64(len(positions))))
+}
+
+// lightVector is the direction from point towards a light position, or the
+// position itself for infinitely distant lights given as vectors.
+func lightVector(position, point *tuples.Tuple) *tuples.Tuple {
+	if position.W == 0 {
+		return position.Normalize()
+	}
+	return position.Subtract(point).Normalize()
+}
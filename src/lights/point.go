@@ -1,8 +1,6 @@
 package lights
 
 import (
-	"math"
-
 	"happymonday.dev/ray-tracer/src/shapes"
 	"happymonday.dev/ray-tracer/src/tuples"
 	"happymonday.dev/ray-tracer/src/viz"
@@ -11,47 +9,48 @@ import (
 type PointLight struct {
 	Position  *tuples.Tuple
 	Intensity *viz.Color
+	// Attenuation dims the light with distance, the zero value doesn't.
+	Attenuation Attenuation
 }
 
-func InitPointLight(p *tuples.Tuple, i *viz.Color) *PointLight {
-	return &PointLight{p, i}
+// Attenuation divides a light's intensity by Constant + Linear * d +
+// Quadratic * d^2 at distance d. It only ever dims: wherever the divisor is
+// below 1 it counts as 1, so a Constant under 1 doesn't brighten the light,
+// it just leaves it undimmed until the divisor reaches 1. The coefficients
+// shouldn't be negative.
+type Attenuation struct {
+	Constant  float64
+	Linear    float64
+	Quadratic float64
 }
 
-func (p *PointLight) Lighting(m *shapes.Material, point *tuples.Tuple, eyev *tuples.Tuple, normalv *tuples.Tuple, inShadow bool) *viz.Color {
-	var ambient, diffuse, specular *viz.Color
-	// combine the surface color with the light's color/intensity
-	effectiveColor := m.Color.Multiply(p.Intensity)
-	// find the direction of the light source
-	lightv := p.Position.Subtract(point).Normalize()
-	ambient = effectiveColor.MultiplyScalar(m.Ambient)
-	if inShadow {
-		return ambient
+// Factor is the fraction of the light left after travelling distance d, at
+// most 1.
+func (a Attenuation) Factor(d float64) float64 {
+	divisor := a.Constant + a.Linear*d + a.Quadratic*d*d
+	if divisor <= 1 {
+		return 1
 	}
-	// lightDotNormal represents the cosine of the angle between the
-	// light vector and the normal vector. A negative number means the light is on the other
-	// side of the surface.
-	lightDotNormal := lightv.DotProduct(normalv)
-	if lightDotNormal < 0 {
-		diffuse = viz.Black()
-		specular = viz.Black()
-	} else {
-		// compute the diffuse contribution
-		diffuse = effectiveColor.MultiplyScalar(m.Diffuse * lightDotNormal)
-
-		// reflectDotEye represents the cosine of the angle between the reflection vector
-		// and the eye vector. A negative number means the light reflects away from the eye.
-		reflectv := lightv.Negate().Reflect(normalv)
-		reflectDotEye := reflectv.DotProduct(eyev)
-		if reflectDotEye <= 0 {
-			specular = viz.Black()
-		} else {
-			factor := math.Pow(reflectDotEye, m.Shininess)
-			specular = p.Intensity.MultiplyScalar(m.Specular * factor)
-		}
+	return 1 / divisor
+}
+
+func InitPointLight(p *tuples.Tuple, i *viz.Color) *PointLight {
+	return &PointLight{Position: p, Intensity: i}
+}
+
+// IntensityAt is all or nothing for an unattenuated point light, so its
+// shadows have hard edges.
+func (p *PointLight) IntensityAt(point *tuples.Tuple, o Occluder) float64 {
+	if o.IsShadowed(p.Position, point) {
+		return 0
 	}
-	return ambient.Add(diffuse).Add(specular)
+	return p.Attenuation.Factor(p.Position.Subtract(point).Magnitude())
+}
+
+func (p *PointLight) Lighting(m *shapes.Material, object shapes.Shape, point *tuples.Tuple, eyev *tuples.Tuple, normalv *tuples.Tuple, intensity float64) *viz.Color {
+	return phong(m, object, point, eyev, normalv, p.Intensity, []*tuples.Tuple{p.Position}, intensity)
 }
 
 func (p *PointLight) Equals(p2 *PointLight) bool {
-	return p.Intensity.Equals(p2.Intensity) && p.Position.Equals(p2.Position)
+	return p.Intensity.Equals(p2.Intensity) && p.Position.Equals(p2.Position) && p.Attenuation == p2.Attenuation
 }
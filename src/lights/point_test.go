@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/patterns"
 	"happymonday.dev/ray-tracer/src/shapes"
 	"happymonday.dev/ray-tracer/src/tuples"
 	"happymonday.dev/ray-tracer/src/viz"
@@ -84,8 +85,101 @@ func TestLighting(t *testing.T) {
 	}
 	for _, o := range opts {
 		light := InitPointLight(o.point, o.color)
-		result := light.Lighting(m, position, o.eyev, o.normalv, o.inShadow)
+		intensity := 1.0
+		if o.inShadow {
+			intensity = 0
+		}
+		result := light.Lighting(m, shapes.InitSphere(), position, o.eyev, o.normalv, intensity)
 		log.Println(result.Tuple)
 		assert.True(t, o.exp.Equals(result), o.msg)
 	}
 }
+
+func TestLightingWithAPatternApplied(t *testing.T) {
+	m := shapes.DefaultMaterial()
+	m.Pattern = patterns.InitStripePattern(viz.InitColor(1, 1, 1), viz.Black())
+	m.Ambient = 1
+	m.Diffuse = 0
+	m.Specular = 0
+	eyev := tuples.InitVector(0, 0, -1)
+	normalv := tuples.InitVector(0, 0, -1)
+	light := InitPointLight(tuples.InitPoint(0, 0, -10), viz.InitColor(1, 1, 1))
+	s := shapes.InitSphere()
+	c1 := light.Lighting(m, s, tuples.InitPoint(0.9, 0, 0), eyev, normalv, 1)
+	c2 := light.Lighting(m, s, tuples.InitPoint(1.1, 0, 0), eyev, normalv, 1)
+	assert.True(t, viz.InitColor(1, 1, 1).Equals(c1))
+	assert.True(t, viz.Black().Equals(c2))
+}
+
+// occluderFunc lets a function stand in for a world in tests.
+type occluderFunc func(lightPosition, point *tuples.Tuple) bool
+
+func (f occluderFunc) IsShadowed(lightPosition, point *tuples.Tuple) bool {
+	return f(lightPosition, point)
+}
+
+func TestPointLightIntensityIsAllOrNothing(t *testing.T) {
+	light := InitPointLight(tuples.InitPoint(0, 0, -10), viz.InitColor(1, 1, 1))
+	blocked := occluderFunc(func(l, p *tuples.Tuple) bool { return true })
+	clear := occluderFunc(func(l, p *tuples.Tuple) bool { return false })
+	assert.Equal(t, 0.0, light.IntensityAt(tuples.InitPoint(0, 0, 0), blocked))
+	assert.Equal(t, 1.0, light.IntensityAt(tuples.InitPoint(0, 0, 0), clear))
+}
+
+func TestLightingUsesLightIntensityToAttenuateColor(t *testing.T) {
+	type opt struct {
+		intensity float64
+		exp       *viz.Color
+	}
+	opts := []opt{
+		{1, viz.InitColor(1, 1, 1)},
+		{0.5, viz.InitColor(0.55, 0.55, 0.55)},
+		{0, viz.InitColor(0.1, 0.1, 0.1)},
+	}
+	m := shapes.DefaultMaterial()
+	m.Ambient = 0.1
+	m.Diffuse = 0.9
+	m.Specular = 0
+	light := InitPointLight(tuples.InitPoint(0, 0, -10), viz.InitColor(1, 1, 1))
+	pt := tuples.InitPoint(0, 0, -1)
+	eyev := tuples.InitVector(0, 0, -1)
+	normalv := tuples.InitVector(0, 0, -1)
+	for _, o := range opts {
+		c := light.Lighting(m, shapes.InitSphere(), pt, eyev, normalv, o.intensity)
+		assert.True(t, o.exp.Equals(c), "%v", o.intensity)
+	}
+}
+
+func TestAttenuationFactor(t *testing.T) {
+	type opt struct {
+		a   Attenuation
+		d   float64
+		exp float64
+	}
+	opts := []opt{
+		{Attenuation{}, 100, 1},
+		{Attenuation{Constant: 1}, 100, 1},
+		{Attenuation{Constant: 1, Linear: 0.5}, 2, 0.5},
+		{Attenuation{Constant: 1, Linear: 0.5, Quadratic: 0.25}, 2, 1.0 / 3},
+		{Attenuation{Quadratic: 1}, 4, 1.0 / 16},
+		// a divisor below 1 counts as 1 rather than brightening
+		{Attenuation{Constant: 0.5}, 100, 1},
+		{Attenuation{Constant: 0.5, Linear: 1}, 1.5, 0.5},
+		{Attenuation{Quadratic: 1}, 0, 1},
+		{Attenuation{Linear: 1, Quadratic: 1}, 0.1, 1},
+	}
+	for _, o := range opts {
+		assert.InDelta(t, o.exp, o.a.Factor(o.d), 1e-9, "%+v at %v", o.a, o.d)
+	}
+}
+
+func TestAttenuatedPointLightIntensityFallsOffWithDistance(t *testing.T) {
+	light := InitPointLight(tuples.InitPoint(0, 0, -10), viz.InitColor(1, 1, 1))
+	light.Attenuation = Attenuation{Quadratic: 0.01}
+	clear := occluderFunc(func(l, p *tuples.Tuple) bool { return false })
+	blocked := occluderFunc(func(l, p *tuples.Tuple) bool { return true })
+	assert.InDelta(t, 1.0, light.IntensityAt(tuples.InitPoint(0, 0, -10), clear), 1e-9)
+	assert.InDelta(t, 1.0, light.IntensityAt(tuples.InitPoint(0, 0, 0), clear), 1e-9)
+	assert.InDelta(t, 0.25, light.IntensityAt(tuples.InitPoint(0, 0, 10), clear), 1e-9)
+	assert.Equal(t, 0.0, light.IntensityAt(tuples.InitPoint(0, 0, 10), blocked))
+}
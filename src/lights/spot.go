@@ -0,0 +1,53 @@
+package lights
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/shapes"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// SpotLight shines from Position along Direction. Points within InnerAngle of
+// the direction are fully lit, light fades smoothly out to OuterAngle and
+// nothing beyond it is lit. Angles are in radians from the center of the
+// cone.
+type SpotLight struct {
+	Position   *tuples.Tuple
+	Direction  *tuples.Tuple
+	InnerAngle float64
+	OuterAngle float64
+	Intensity  *viz.Color
+}
+
+func InitSpotLight(position, direction *tuples.Tuple, inner, outer float64, intensity *viz.Color) *SpotLight {
+	return &SpotLight{position, direction.Normalize(), inner, outer, intensity}
+}
+
+// Falloff is how much of the light the cone lets through towards point.
+func (s *SpotLight) Falloff(point *tuples.Tuple) float64 {
+	cos := point.Subtract(s.Position).Normalize().DotProduct(s.Direction)
+	cosInner := math.Cos(s.InnerAngle)
+	cosOuter := math.Cos(s.OuterAngle)
+	if cos >= cosInner {
+		return 1
+	}
+	if cos <= cosOuter {
+		return 0
+	}
+	// smoothstep, so there's no visible edge at either angle
+	t := (cos - cosOuter) / (cosInner - cosOuter)
+	return t * t * (3 - 2*t)
+}
+
+func (s *SpotLight) IntensityAt(point *tuples.Tuple, o Occluder) float64 {
+	f := s.Falloff(point)
+	if f == 0 || o.IsShadowed(s.Position, point) {
+		return 0
+	}
+	return f
+}
+
+func (s *SpotLight) Lighting(m *shapes.Material, object shapes.Shape, point *tuples.Tuple, eyev *tuples.Tuple, normalv *tuples.Tuple, intensity float64) *viz.Color {
+	return phong(m, object, point, eyev, normalv, s.Intensity, []*tuples.Tuple{s.Position}, intensity)
+}
@@ -0,0 +1,72 @@
+package lights
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/shapes"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+func TestCreatingASpotLightNormalizesItsDirection(t *testing.T) {
+	l := InitSpotLight(tuples.InitPoint(0, 10, 0), tuples.InitVector(0, -5, 0), math.Pi/8, math.Pi/4, viz.InitColor(1, 1, 1))
+	assert.True(t, tuples.InitVector(0, -1, 0).Equals(l.Direction))
+}
+
+func TestSpotLightFalloff(t *testing.T) {
+	type opt struct {
+		s   string
+		p   *tuples.Tuple
+		exp float64
+	}
+	// The inner cone is 30 degrees and the outer 60, measured at one unit
+	// below the light.
+	opts := []opt{
+		{"on the axis", tuples.InitPoint(0, -10, 0), 1},
+		{"inside the inner cone", tuples.InitPoint(0.5, -1, 0), 1},
+		{"on the inner edge", tuples.InitPoint(math.Tan(math.Pi/6), -1, 0), 1},
+		{"half way between edges in cosine", tuples.InitPoint(math.Tan(math.Acos((math.Cos(math.Pi/6)+0.5)/2)), -1, 0), 0.5},
+		{"on the outer edge", tuples.InitPoint(0, -1, math.Tan(math.Pi/3)), 0},
+		{"outside the cone", tuples.InitPoint(5, -1, 0), 0},
+		{"behind the light", tuples.InitPoint(0, 1, 0), 0},
+	}
+	l := InitSpotLight(tuples.InitPoint(0, 0, 0), tuples.InitVector(0, -1, 0), math.Pi/6, math.Pi/3, viz.InitColor(1, 1, 1))
+	for _, o := range opts {
+		assert.True(t, maths.FuzzyEquals(o.exp, l.Falloff(o.p)), "%s: %v", o.s, l.Falloff(o.p))
+	}
+}
+
+func TestSpotLightFalloffIsSmooth(t *testing.T) {
+	l := InitSpotLight(tuples.InitPoint(0, 0, 0), tuples.InitVector(0, -1, 0), math.Pi/6, math.Pi/3, viz.InitColor(1, 1, 1))
+	prev := 1.0
+	for x := 0.0; x < 2; x += 0.05 {
+		f := l.Falloff(tuples.InitPoint(x, -1, 0))
+		assert.LessOrEqual(t, f, prev)
+		prev = f
+	}
+}
+
+func TestSpotLightIntensity(t *testing.T) {
+	l := InitSpotLight(tuples.InitPoint(0, 0, 0), tuples.InitVector(0, -1, 0), math.Pi/6, math.Pi/3, viz.InitColor(1, 1, 1))
+	calls := 0
+	clear := occluderFunc(func(lp, p *tuples.Tuple) bool { calls++; return false })
+	blocked := occluderFunc(func(lp, p *tuples.Tuple) bool { return true })
+	assert.Equal(t, 1.0, l.IntensityAt(tuples.InitPoint(0, -1, 0), clear))
+	assert.Equal(t, 0.0, l.IntensityAt(tuples.InitPoint(0, -1, 0), blocked))
+	// Points outside the cone don't need a shadow ray.
+	calls = 0
+	assert.Equal(t, 0.0, l.IntensityAt(tuples.InitPoint(5, -1, 0), clear))
+	assert.Equal(t, 0, calls)
+}
+
+func TestLightingWithASpotLight(t *testing.T) {
+	m := shapes.DefaultMaterial()
+	l := InitSpotLight(tuples.InitPoint(0, 0, -10), tuples.InitVector(0, 0, 1), math.Pi/6, math.Pi/3, viz.InitColor(1, 1, 1))
+	eyev := tuples.InitVector(0, 0, -1)
+	normalv := tuples.InitVector(0, 0, -1)
+	c := l.Lighting(m, shapes.InitSphere(), tuples.InitPoint(0, 0, 0), eyev, normalv, 1)
+	assert.True(t, viz.InitColor(1.9, 1.9, 1.9).Equals(c))
+}
@@ -0,0 +1,22 @@
+package maths
+
+import "math"
+
+// PointJitter returns random numbers from 0 to 1 in a sequence seeded from a
+// point, so sampling around the same point draws the same numbers whichever
+// goroutine does it and renders repeat exactly. Each sequence belongs to one
+// goroutine, make a new one per point.
+func PointJitter(x, y, z float64) func() float64 {
+	s := math.Float64bits(x)*0x9e3779b97f4a7c15 ^
+		math.Float64bits(y)*0xc2b2ae3d27d4eb4f ^
+		math.Float64bits(z)*0x165667b19e3779f9
+	return func() float64 {
+		// splitmix64
+		s += 0x9e3779b97f4a7c15
+		v := s
+		v = (v ^ v>>30) * 0xbf58476d1ce4e5b9
+		v = (v ^ v>>27) * 0x94d049bb133111eb
+		v ^= v >> 31
+		return float64(v>>11) / (1 << 53)
+	}
+}
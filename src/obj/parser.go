@@ -0,0 +1,229 @@
+package obj
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"happymonday.dev/ray-tracer/src/shapes"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+// DefaultGroup holds faces that appear before any named group.
+const DefaultGroup = ""
+
+// LineError describes a line that looked like a statement we understand but
+// couldn't be parsed.
+type LineError struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d %q: %s", e.Line, e.Text, e.Reason)
+}
+
+// Parser holds everything read from a Wavefront OBJ file. Statements other
+// than vertices, normals, texture coordinates, faces and groups are counted
+// in Ignored and otherwise skipped.
+type Parser struct {
+	Vertices      []*tuples.Tuple
+	Normals       []*tuples.Tuple
+	TextureCoords []*tuples.Tuple
+	// Groups maps group names to their triangles, GroupNames has the names
+	// in the order they first appear
+	Groups     map[string][]shapes.Shape
+	GroupNames []string
+	Ignored    int
+	Malformed  []*LineError
+
+	group string
+}
+
+func ParseFile(path string) (*Parser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads OBJ data from r. Only failing to read returns an error, bad
+// lines are collected in Malformed.
+func Parse(r io.Reader) (*Parser, error) {
+	p := &Parser{Groups: map[string][]shapes.Shape{}}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if err := p.parseLine(text); err != nil {
+			p.Malformed = append(p.Malformed, &LineError{line, text, err.Error()})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Parser) parseLine(text string) error {
+	if i := strings.Index(text, "#"); i >= 0 {
+		text = text[:i]
+	}
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return nil
+	}
+	args := fields[1:]
+	switch fields[0] {
+	case "v":
+		v, err := parseFloats(args, 3, 4)
+		if err != nil {
+			return err
+		}
+		p.Vertices = append(p.Vertices, tuples.InitPoint(v[0], v[1], v[2]))
+	case "vn":
+		v, err := parseFloats(args, 3, 3)
+		if err != nil {
+			return err
+		}
+		p.Normals = append(p.Normals, tuples.InitVector(v[0], v[1], v[2]))
+	case "vt":
+		v, err := parseFloats(args, 1, 3)
+		if err != nil {
+			return err
+		}
+		for len(v) < 3 {
+			v = append(v, 0)
+		}
+		p.TextureCoords = append(p.TextureCoords, tuples.InitVector(v[0], v[1], v[2]))
+	case "f":
+		return p.parseFace(args)
+	case "g":
+		p.group = strings.Join(args, " ")
+	default:
+		p.Ignored++
+	}
+	return nil
+}
+
+func parseFloats(args []string, min, max int) ([]float64, error) {
+	if len(args) < min || len(args) > max {
+		return nil, fmt.Errorf("expected %d to %d values, got %d", min, max, len(args))
+	}
+	res := []float64{}
+	for _, a := range args {
+		f, err := strconv.ParseFloat(a, 64)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, f)
+	}
+	return res, nil
+}
+
+type faceVertex struct {
+	vertex *tuples.Tuple
+	normal *tuples.Tuple
+}
+
+// parseFace triangulates the polygon as a fan around its first vertex. Faces
+// where every vertex has a normal become smooth triangles.
+func (p *Parser) parseFace(args []string) error {
+	if len(args) < 3 {
+		return fmt.Errorf("faces need at least 3 vertices, got %d", len(args))
+	}
+	vs := []faceVertex{}
+	smooth := true
+	for _, a := range args {
+		parts := strings.Split(a, "/")
+		if len(parts) > 3 {
+			return fmt.Errorf("bad face vertex %q", a)
+		}
+		fv := faceVertex{}
+		v, err := index(parts[0], len(p.Vertices))
+		if err != nil {
+			return err
+		}
+		fv.vertex = p.Vertices[v]
+		if len(parts) > 1 && parts[1] != "" {
+			if _, err := index(parts[1], len(p.TextureCoords)); err != nil {
+				return err
+			}
+		}
+		if len(parts) > 2 && parts[2] != "" {
+			n, err := index(parts[2], len(p.Normals))
+			if err != nil {
+				return err
+			}
+			fv.normal = p.Normals[n]
+		} else {
+			smooth = false
+		}
+		vs = append(vs, fv)
+	}
+
+	if _, ok := p.Groups[p.group]; !ok {
+		p.GroupNames = append(p.GroupNames, p.group)
+	}
+	for i := 1; i < len(vs)-1; i++ {
+		var t shapes.Shape
+		if smooth {
+			t = shapes.InitSmoothTriangle(vs[0].vertex, vs[i].vertex, vs[i+1].vertex, vs[0].normal, vs[i].normal, vs[i+1].normal)
+		} else {
+			t = shapes.InitTriangle(vs[0].vertex, vs[i].vertex, vs[i+1].vertex)
+		}
+		p.Groups[p.group] = append(p.Groups[p.group], t)
+	}
+	return nil
+}
+
+// index converts a 1 based (or negative, counting back from the latest)
+// OBJ index into a slice index.
+func index(s string, count int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if i < 0 {
+		i = count + i + 1
+	}
+	if i < 1 || i > count {
+		return 0, fmt.Errorf("index %s out of range for %d entries", s, count)
+	}
+	return i - 1, nil
+}
+
+// Shapes returns the triangles from every group, ready for World.Objects.
+func (p *Parser) Shapes() []shapes.Shape {
+	res := []shapes.Shape{}
+	for _, name := range p.GroupNames {
+		res = append(res, p.Groups[name]...)
+	}
+	return res
+}
+
+// ToGroup returns a group holding a sub group for each named group in the
+// file, so the whole model can be transformed as one. Triangles already
+// grouped by an earlier call are cloned, so each call gives a separate model.
+func (p *Parser) ToGroup() *shapes.Group {
+	g := shapes.InitGroup()
+	for _, name := range p.GroupNames {
+		sub := shapes.InitGroup()
+		for _, s := range p.Groups[name] {
+			if s.Parent() != nil {
+				s = s.Clone()
+			}
+			sub.AddChild(s)
+		}
+		g.AddChild(sub)
+	}
+	return g
+}
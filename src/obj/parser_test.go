@@ -0,0 +1,219 @@
+package obj
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/shapes"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+func TestIgnoringUnrecognizedLines(t *testing.T) {
+	p, err := Parse(strings.NewReader(`There was a young lady named Bright
+who traveled much faster than light.
+She set out one day
+in a relative way,
+and came back the previous night.
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, 5, p.Ignored)
+	assert.Empty(t, p.Malformed)
+}
+
+func TestVertexRecords(t *testing.T) {
+	p, err := Parse(strings.NewReader(`
+v -1 1 0
+v -1.0000 0.5000 0.0000
+v 1 0 0
+v 1 1 0
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(p.Vertices))
+	assert.True(t, tuples.InitPoint(-1, 1, 0).Equals(p.Vertices[0]))
+	assert.True(t, tuples.InitPoint(-1, 0.5, 0).Equals(p.Vertices[1]))
+	assert.True(t, tuples.InitPoint(1, 0, 0).Equals(p.Vertices[2]))
+	assert.True(t, tuples.InitPoint(1, 1, 0).Equals(p.Vertices[3]))
+}
+
+func TestParsingTriangleFaces(t *testing.T) {
+	p, _ := Parse(strings.NewReader(`
+v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+f 1 2 3
+f 1 3 4
+`))
+	ts := p.Groups[DefaultGroup]
+	assert.Equal(t, 2, len(ts))
+	t1 := ts[0].(*shapes.Triangle)
+	t2 := ts[1].(*shapes.Triangle)
+	assert.True(t, p.Vertices[0].Equals(t1.P1))
+	assert.True(t, p.Vertices[1].Equals(t1.P2))
+	assert.True(t, p.Vertices[2].Equals(t1.P3))
+	assert.True(t, p.Vertices[0].Equals(t2.P1))
+	assert.True(t, p.Vertices[2].Equals(t2.P2))
+	assert.True(t, p.Vertices[3].Equals(t2.P3))
+}
+
+func TestTriangulatingPolygons(t *testing.T) {
+	p, _ := Parse(strings.NewReader(`
+v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+v 0 2 0
+
+f 1 2 3 4 5
+`))
+	ts := p.Groups[DefaultGroup]
+	assert.Equal(t, 3, len(ts))
+	for i, tri := range ts {
+		tri := tri.(*shapes.Triangle)
+		assert.True(t, p.Vertices[0].Equals(tri.P1))
+		assert.True(t, p.Vertices[i+1].Equals(tri.P2))
+		assert.True(t, p.Vertices[i+2].Equals(tri.P3))
+	}
+}
+
+func TestTrianglesInGroups(t *testing.T) {
+	p, _ := Parse(strings.NewReader(`
+v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+g FirstGroup
+f 1 2 3
+g SecondGroup
+f 1 3 4
+`))
+	assert.Equal(t, []string{"FirstGroup", "SecondGroup"}, p.GroupNames)
+	t1 := p.Groups["FirstGroup"][0].(*shapes.Triangle)
+	t2 := p.Groups["SecondGroup"][0].(*shapes.Triangle)
+	assert.True(t, p.Vertices[0].Equals(t1.P1))
+	assert.True(t, p.Vertices[3].Equals(t2.P3))
+	assert.Equal(t, 2, len(p.Shapes()))
+}
+
+func TestConvertingAnOBJFileToAGroup(t *testing.T) {
+	p, _ := Parse(strings.NewReader(`
+v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+g FirstGroup
+f 1 2 3
+g SecondGroup
+f 1 3 4
+`))
+	g := p.ToGroup()
+	assert.Equal(t, 2, len(g.Children))
+	for i, name := range p.GroupNames {
+		sub := g.Children[i].(*shapes.Group)
+		assert.True(t, g.Equals(sub.Parent()))
+		assert.True(t, p.Groups[name][0].Equals(sub.Children[0]))
+	}
+}
+
+func TestConvertingAnOBJFileToAGroupTwice(t *testing.T) {
+	p, _ := Parse(strings.NewReader(`
+v -1 1 0
+v -1 0 0
+v 1 0 0
+
+f 1 2 3
+`))
+	g1 := p.ToGroup()
+	g2 := p.ToGroup()
+	t1 := g1.Children[0].(*shapes.Group).Children[0]
+	t2 := g2.Children[0].(*shapes.Group).Children[0]
+	assert.False(t, t1.Equals(t2))
+	assert.True(t, g2.Children[0].Equals(t2.Parent()))
+}
+
+func TestVertexNormalAndTextureRecords(t *testing.T) {
+	p, _ := Parse(strings.NewReader(`
+vn 0 0 1
+vn 0.707 0 -0.707
+vn 1 2 3
+vt 0.5 0.25
+`))
+	assert.True(t, tuples.InitVector(0, 0, 1).Equals(p.Normals[0]))
+	assert.True(t, tuples.InitVector(0.707, 0, -0.707).Equals(p.Normals[1]))
+	assert.True(t, tuples.InitVector(1, 2, 3).Equals(p.Normals[2]))
+	assert.True(t, tuples.InitVector(0.5, 0.25, 0).Equals(p.TextureCoords[0]))
+}
+
+func TestFacesWithNormals(t *testing.T) {
+	p, _ := Parse(strings.NewReader(`
+v 0 1 0
+v -1 0 0
+v 1 0 0
+
+vn -1 0 0
+vn 1 0 0
+vn 0 1 0
+
+vt 0 0
+
+f 1//3 2//1 3//2
+f 1/1/3 2/1/1 3/1/2
+f -3//-1 -2//-3 -1//-2
+`))
+	assert.Empty(t, p.Malformed)
+	for _, s := range p.Groups[DefaultGroup] {
+		tri := s.(*shapes.SmoothTriangle)
+		assert.True(t, p.Vertices[0].Equals(tri.P1))
+		assert.True(t, p.Vertices[1].Equals(tri.P2))
+		assert.True(t, p.Vertices[2].Equals(tri.P3))
+		assert.True(t, p.Normals[2].Equals(tri.N1))
+		assert.True(t, p.Normals[0].Equals(tri.N2))
+		assert.True(t, p.Normals[1].Equals(tri.N3))
+	}
+}
+
+func TestMalformedLinesAreReported(t *testing.T) {
+	p, err := Parse(strings.NewReader(`v 1 2
+v 0 0 0
+v 1 x 0
+f 1 2
+f 1 2 3
+vn 1 1
+v 1 0 0
+v 0 1 0
+f 1 2 3 # fine
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(p.Malformed))
+	assert.Equal(t, []int{1, 3, 4, 5, 6}, []int{
+		p.Malformed[0].Line,
+		p.Malformed[1].Line,
+		p.Malformed[2].Line,
+		p.Malformed[3].Line,
+		p.Malformed[4].Line,
+	})
+	assert.Equal(t, 1, len(p.Shapes()))
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add("v 1 2 3\nv 4 5 6\nv 7 8 9\nf 1 2 3\n")
+	f.Add("v 0 1 0\nv -1 0 0\nv 1 0 0\nvn 0 0 1\nvt 0.5 0.5\ng a b\nf 1/1/1 2/1/1 3/1/1\n")
+	f.Add("f -1 -2 -3\nf 1//\nf 0 0 0\n# comment\nv nan inf -inf\n")
+	f.Fuzz(func(t *testing.T, data string) {
+		p, err := Parse(strings.NewReader(data))
+		if err != nil {
+			return
+		}
+		lines := strings.Count(data, "\n") + 1
+		if len(p.Malformed)+p.Ignored > lines {
+			t.Errorf("%d malformed and %d ignored from %d lines", len(p.Malformed), p.Ignored, lines)
+		}
+		for _, s := range p.Shapes() {
+			s.Bounds()
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package patterns
+
+import (
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// Blend averages the colors of two patterns at each point.
+type Blend struct {
+	*PatternEmbed
+	A Pattern
+	B Pattern
+}
+
+func InitBlendPattern(a, b Pattern) *Blend {
+	return &Blend{InitPatternEmbed(), a, b}
+}
+
+func (b *Blend) ColorAt(p *tuples.Tuple) *viz.Color {
+	return At(b.A, p).Add(At(b.B, p)).MultiplyScalar(0.5)
+}
@@ -0,0 +1,31 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+func TestABlendAveragesItsPatterns(t *testing.T) {
+	type opt struct {
+		s   string
+		p   *tuples.Tuple
+		exp *viz.Color
+	}
+	a := InitStripePattern(white, black)
+	b := InitStripePattern(white, black)
+	b.SetTransform(matrix.Scaling(2, 1, 1))
+	opts := []opt{
+		{"both white", tuples.InitPoint(0.5, 0, 0), white},
+		{"black and white", tuples.InitPoint(1.5, 0, 0), viz.InitColor(0.5, 0.5, 0.5)},
+		{"white and black", tuples.InitPoint(2.5, 0, 0), viz.InitColor(0.5, 0.5, 0.5)},
+		{"both black", tuples.InitPoint(3.5, 0, 0), black},
+	}
+	p := InitBlendPattern(a, b)
+	for _, o := range opts {
+		assert.True(t, o.exp.Equals(p.ColorAt(o.p)), o.s)
+	}
+}
@@ -0,0 +1,32 @@
+package patterns
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// Checkers alternates between A and B in unit cubes.
+type Checkers struct {
+	*PatternEmbed
+	A Pattern
+	B Pattern
+}
+
+func InitCheckersPattern(a, b *viz.Color) *Checkers {
+	return InitCheckersPatternOf(InitSolidPattern(a), InitSolidPattern(b))
+}
+
+// InitCheckersPatternOf uses patterns in place of A and B, each nested in
+// this pattern's space.
+func InitCheckersPatternOf(a, b Pattern) *Checkers {
+	return &Checkers{InitPatternEmbed(), a, b}
+}
+
+func (c *Checkers) ColorAt(p *tuples.Tuple) *viz.Color {
+	if math.Mod(math.Floor(p.X)+math.Floor(p.Y)+math.Floor(p.Z), 2) == 0 {
+		return At(c.A, p)
+	}
+	return At(c.B, p)
+}
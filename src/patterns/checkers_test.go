@@ -0,0 +1,49 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+func TestCheckersShouldRepeatInEachDimension(t *testing.T) {
+	type opt struct {
+		s   string
+		p   *tuples.Tuple
+		exp bool
+	}
+	opts := []opt{
+		{"x", tuples.InitPoint(0.99, 0, 0), true},
+		{"x", tuples.InitPoint(1.01, 0, 0), false},
+		{"y", tuples.InitPoint(0, 0.99, 0), true},
+		{"y", tuples.InitPoint(0, 1.01, 0), false},
+		{"z", tuples.InitPoint(0, 0, 0.99), true},
+		{"z", tuples.InitPoint(0, 0, 1.01), false},
+		{"negative", tuples.InitPoint(-0.5, -0.5, 0.5), true},
+	}
+	p := InitCheckersPattern(white, black)
+	for _, o := range opts {
+		assert.Equal(t, o.exp, white.Equals(p.ColorAt(o.p)), o.s)
+	}
+}
+
+func TestCheckersOfStripes(t *testing.T) {
+	type opt struct {
+		s   string
+		p   *tuples.Tuple
+		exp bool
+	}
+	opts := []opt{
+		{"white stripe in checker", tuples.InitPoint(0.5, 0, 0), true},
+		{"black stripe in checker", tuples.InitPoint(2.5, 0, 0), false},
+		{"black checker", tuples.InitPoint(0.5, 0, 1.5), false},
+	}
+	stripes := InitStripePattern(white, black)
+	stripes.SetTransform(matrix.Scaling(2, 2, 2))
+	p := InitCheckersPatternOf(stripes, InitSolidPattern(black))
+	for _, o := range opts {
+		assert.Equal(t, o.exp, white.Equals(p.ColorAt(o.p)), o.s)
+	}
+}
@@ -0,0 +1,76 @@
+package patterns
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+type CubeFace int
+
+const (
+	CubeLeft CubeFace = iota
+	CubeFront
+	CubeRight
+	CubeBack
+	CubeUp
+	CubeDown
+)
+
+// CubeFaceOf is the face of the unit cube a point lies on, picked by its
+// largest coordinate.
+func CubeFaceOf(p *tuples.Tuple) CubeFace {
+	coord := math.Max(math.Abs(p.X), math.Max(math.Abs(p.Y), math.Abs(p.Z)))
+	switch coord {
+	case p.X:
+		return CubeRight
+	case -p.X:
+		return CubeLeft
+	case p.Y:
+		return CubeUp
+	case -p.Y:
+		return CubeDown
+	case p.Z:
+		return CubeFront
+	}
+	return CubeBack
+}
+
+// CubeFaceUV maps a point on a face of the unit cube to that face's texture,
+// oriented as if looking at the face from outside with up towards +y (or
+// towards -z for the top and bottom).
+func CubeFaceUV(face CubeFace, p *tuples.Tuple) (float64, float64) {
+	var u, v float64
+	switch face {
+	case CubeLeft:
+		u, v = p.Z+1, p.Y+1
+	case CubeFront:
+		u, v = p.X+1, p.Y+1
+	case CubeRight:
+		u, v = 1-p.Z, p.Y+1
+	case CubeBack:
+		u, v = 1-p.X, p.Y+1
+	case CubeUp:
+		u, v = p.X+1, 1-p.Z
+	case CubeDown:
+		u, v = p.X+1, p.Z+1
+	}
+	return math.Mod(u, 2) / 2, math.Mod(v, 2) / 2
+}
+
+// CubeMap textures each face of a unit cube separately. Faces is indexed by
+// CubeFace.
+type CubeMap struct {
+	*PatternEmbed
+	Faces [6]UVPattern
+}
+
+func InitCubeMapPattern(left, front, right, back, up, down UVPattern) *CubeMap {
+	return &CubeMap{InitPatternEmbed(), [6]UVPattern{left, front, right, back, up, down}}
+}
+
+func (c *CubeMap) ColorAt(p *tuples.Tuple) *viz.Color {
+	face := CubeFaceOf(p)
+	return c.Faces[face].UVColorAt(CubeFaceUV(face, p))
+}
@@ -0,0 +1,89 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+func TestIdentifyingTheFaceOfACubeFromAPoint(t *testing.T) {
+	type opt struct {
+		p   *tuples.Tuple
+		exp CubeFace
+	}
+	opts := []opt{
+		{tuples.InitPoint(-1, 0.5, -0.25), CubeLeft},
+		{tuples.InitPoint(1.1, -0.75, 0.8), CubeRight},
+		{tuples.InitPoint(0.1, 0.6, 0.9), CubeFront},
+		{tuples.InitPoint(-0.7, 0, -2), CubeBack},
+		{tuples.InitPoint(0.5, 1, 0.9), CubeUp},
+		{tuples.InitPoint(-0.2, -1.3, 1.1), CubeDown},
+	}
+	for _, o := range opts {
+		assert.Equal(t, o.exp, CubeFaceOf(o.p), "%v", o.p)
+	}
+}
+
+func TestUVMappingOfCubeFaces(t *testing.T) {
+	type opt struct {
+		face CubeFace
+		p    *tuples.Tuple
+		u    float64
+		v    float64
+	}
+	opts := []opt{
+		{CubeFront, tuples.InitPoint(-0.5, 0.5, 1), 0.25, 0.75},
+		{CubeFront, tuples.InitPoint(0.5, -0.5, 1), 0.75, 0.25},
+		{CubeBack, tuples.InitPoint(0.5, 0.5, -1), 0.25, 0.75},
+		{CubeBack, tuples.InitPoint(-0.5, -0.5, -1), 0.75, 0.25},
+		{CubeLeft, tuples.InitPoint(-1, 0.5, -0.5), 0.25, 0.75},
+		{CubeLeft, tuples.InitPoint(-1, -0.5, 0.5), 0.75, 0.25},
+		{CubeRight, tuples.InitPoint(1, 0.5, 0.5), 0.25, 0.75},
+		{CubeRight, tuples.InitPoint(1, -0.5, -0.5), 0.75, 0.25},
+		{CubeUp, tuples.InitPoint(-0.5, 1, -0.5), 0.25, 0.75},
+		{CubeUp, tuples.InitPoint(0.5, 1, 0.5), 0.75, 0.25},
+		{CubeDown, tuples.InitPoint(-0.5, -1, 0.5), 0.25, 0.75},
+		{CubeDown, tuples.InitPoint(0.5, -1, -0.5), 0.75, 0.25},
+	}
+	for _, o := range opts {
+		u, v := CubeFaceUV(o.face, o.p)
+		assert.Equal(t, o.u, u, "u at %v", o.p)
+		assert.Equal(t, o.v, v, "v at %v", o.p)
+	}
+}
+
+func TestFindingTheColorsOnAMappedCube(t *testing.T) {
+	type opt struct {
+		s   string
+		p   *tuples.Tuple
+		exp *viz.Color
+	}
+	opts := []opt{
+		{"left main", tuples.InitPoint(-1, 0, 0), yellow},
+		{"left upper left", tuples.InitPoint(-1, 0.9, -0.9), cyan},
+		{"left upper right", tuples.InitPoint(-1, 0.9, 0.9), red},
+		{"left bottom left", tuples.InitPoint(-1, -0.9, -0.9), blue},
+		{"left bottom right", tuples.InitPoint(-1, -0.9, 0.9), brown},
+		{"front main", tuples.InitPoint(0, 0, 1), cyan},
+		{"front upper left", tuples.InitPoint(-0.9, 0.9, 1), red},
+		{"front bottom right", tuples.InitPoint(0.9, -0.9, 1), purple},
+		{"right main", tuples.InitPoint(1, 0, 0), red},
+		{"back main", tuples.InitPoint(0, 0, -1), green},
+		{"up main", tuples.InitPoint(0, 1, 0), brown},
+		{"up upper left", tuples.InitPoint(-0.9, 1, -0.9), cyan},
+		{"down main", tuples.InitPoint(0, -1, 0), purple},
+		{"down upper left", tuples.InitPoint(-0.9, -1, 0.9), brown},
+	}
+	left := InitUVAlignCheckPattern(yellow, cyan, red, blue, brown)
+	front := InitUVAlignCheckPattern(cyan, red, yellow, brown, purple)
+	right := InitUVAlignCheckPattern(red, yellow, purple, green, white)
+	back := InitUVAlignCheckPattern(green, purple, cyan, white, blue)
+	up := InitUVAlignCheckPattern(brown, cyan, purple, red, yellow)
+	down := InitUVAlignCheckPattern(purple, brown, green, blue, white)
+	p := InitCubeMapPattern(left, front, right, back, up, down)
+	for _, o := range opts {
+		assert.True(t, o.exp.Equals(p.ColorAt(o.p)), o.s)
+	}
+}
@@ -0,0 +1,31 @@
+package patterns
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// Gradient blends linearly from A to B across each unit along x.
+type Gradient struct {
+	*PatternEmbed
+	A Pattern
+	B Pattern
+}
+
+func InitGradientPattern(a, b *viz.Color) *Gradient {
+	return InitGradientPatternOf(InitSolidPattern(a), InitSolidPattern(b))
+}
+
+// InitGradientPatternOf uses patterns in place of A and B, each nested in
+// this pattern's space.
+func InitGradientPatternOf(a, b Pattern) *Gradient {
+	return &Gradient{InitPatternEmbed(), a, b}
+}
+
+func (g *Gradient) ColorAt(p *tuples.Tuple) *viz.Color {
+	a := At(g.A, p)
+	b := At(g.B, p)
+	return a.Add(b.Subtract(a).MultiplyScalar(p.X - math.Floor(p.X)))
+}
@@ -0,0 +1,17 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+func TestAGradientLinearlyInterpolatesBetweenColors(t *testing.T) {
+	p := InitGradientPattern(white, black)
+	assert.True(t, white.Equals(p.ColorAt(tuples.InitPoint(0, 0, 0))))
+	assert.True(t, viz.InitColor(0.75, 0.75, 0.75).Equals(p.ColorAt(tuples.InitPoint(0.25, 0, 0))))
+	assert.True(t, viz.InitColor(0.5, 0.5, 0.5).Equals(p.ColorAt(tuples.InitPoint(0.5, 0, 0))))
+	assert.True(t, viz.InitColor(0.25, 0.25, 0.25).Equals(p.ColorAt(tuples.InitPoint(0.75, 0, 0))))
+}
@@ -0,0 +1,102 @@
+package patterns
+
+import (
+	"errors"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"os"
+
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+type TextureFilter int
+
+const (
+	// FilterNearest uses the color of the pixel a point falls in.
+	FilterNearest TextureFilter = iota
+	// FilterBilinear blends the four pixels whose centers surround a point.
+	FilterBilinear
+)
+
+type TextureAddress int
+
+const (
+	// AddressWrap tiles the image outside 0..1.
+	AddressWrap TextureAddress = iota
+	// AddressClamp repeats the edge pixels outside 0..1.
+	AddressClamp
+)
+
+// ImageTexture is a UV pattern sampled from a canvas. u runs left to right and
+// v bottom to top, so the top row of the image is at v = 1.
+type ImageTexture struct {
+	Canvas  *viz.Canvas
+	Filter  TextureFilter
+	Address TextureAddress
+}
+
+// InitImageTexture errors if the canvas has no pixels to sample.
+func InitImageTexture(c *viz.Canvas) (*ImageTexture, error) {
+	if c.Width < 1 || c.Height < 1 {
+		return nil, errors.New("image texture: image has no pixels")
+	}
+	return &ImageTexture{Canvas: c}, nil
+}
+
+// DecodeImageTexture reads any image format registered with the image
+// package, which includes PNG, JPEG and PPM.
+func DecodeImageTexture(r io.Reader) (*ImageTexture, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	c := viz.CanvasFromImage(img)
+	return InitImageTexture(&c)
+}
+
+func LoadImageTexture(path string) (*ImageTexture, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return DecodeImageTexture(f)
+}
+
+func (t *ImageTexture) UVColorAt(u, v float64) *viz.Color {
+	x := u * float64(t.Canvas.Width)
+	y := (1 - v) * float64(t.Canvas.Height)
+	if t.Filter == FilterNearest {
+		return t.pixel(int(math.Floor(x)), int(math.Floor(y)))
+	}
+
+	// Shift to pixel centers so blending happens between neighbours.
+	x -= 0.5
+	y -= 0.5
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := x-x0, y-y0
+	ix, iy := int(x0), int(y0)
+	top := t.pixel(ix, iy).MultiplyScalar(1 - fx).Add(t.pixel(ix+1, iy).MultiplyScalar(fx))
+	bottom := t.pixel(ix, iy+1).MultiplyScalar(1 - fx).Add(t.pixel(ix+1, iy+1).MultiplyScalar(fx))
+	return top.MultiplyScalar(1 - fy).Add(bottom.MultiplyScalar(fy))
+}
+
+func (t *ImageTexture) pixel(x, y int) *viz.Color {
+	return t.Canvas.Pixel(t.address(x, t.Canvas.Width), t.address(y, t.Canvas.Height))
+}
+
+func (t *ImageTexture) address(i, n int) int {
+	if t.Address == AddressClamp {
+		if i < 0 {
+			return 0
+		}
+		if i >= n {
+			return n - 1
+		}
+		return i
+	}
+	return ((i % n) + n) % n
+}
@@ -0,0 +1,109 @@
+package patterns
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// testTexture is 2x2 with red and green on the top row, blue and white below.
+func testTexture() *ImageTexture {
+	c := viz.InitCanvas(2, 2)
+	c.SetPixel(red, 0, 0)
+	c.SetPixel(green, 1, 0)
+	c.SetPixel(blue, 0, 1)
+	c.SetPixel(white, 1, 1)
+	tex, _ := InitImageTexture(&c)
+	return tex
+}
+
+func TestNearestImageTextureSampling(t *testing.T) {
+	type opt struct {
+		s   string
+		u   float64
+		v   float64
+		exp *viz.Color
+	}
+	opts := []opt{
+		{"top left", 0.25, 0.75, red},
+		{"top right", 0.75, 0.75, green},
+		{"bottom left", 0.25, 0.25, blue},
+		{"bottom right", 0.75, 0.25, white},
+		{"wraps past the right edge", 1.25, 0.75, red},
+		{"wraps below the bottom edge", 0.25, -0.25, red},
+	}
+	tex := testTexture()
+	for _, o := range opts {
+		assert.True(t, o.exp.Equals(tex.UVColorAt(o.u, o.v)), o.s)
+	}
+}
+
+func TestClampedImageTextureSampling(t *testing.T) {
+	tex := testTexture()
+	tex.Address = AddressClamp
+	assert.True(t, green.Equals(tex.UVColorAt(1.25, 0.75)))
+	assert.True(t, blue.Equals(tex.UVColorAt(0.25, -0.25)))
+}
+
+func TestBilinearImageTextureSampling(t *testing.T) {
+	type opt struct {
+		s       string
+		address TextureAddress
+		u       float64
+		v       float64
+		exp     *viz.Color
+	}
+	opts := []opt{
+		{"pixel center", AddressWrap, 0.25, 0.75, red},
+		{"between top pixels", AddressWrap, 0.5, 0.75, viz.InitColor(0.5, 0.5, 0)},
+		{"between all four", AddressWrap, 0.5, 0.5, viz.InitColor(0.5, 0.5, 0.5)},
+		{"wrapping at the edge", AddressWrap, 0, 0.75, viz.InitColor(0.5, 0.5, 0)},
+		{"clamping at the edge", AddressClamp, 0, 0.75, red},
+	}
+	tex := testTexture()
+	tex.Filter = FilterBilinear
+	for _, o := range opts {
+		tex.Address = o.address
+		assert.True(t, o.exp.Equals(tex.UVColorAt(o.u, o.v)), o.s)
+	}
+}
+
+func TestDecodingAPNGImageTexture(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.RGBA{0xff, 0, 0, 0xff})
+	img.Set(1, 0, color.RGBA{0, 0, 0xff, 0xff})
+	buf := bytes.Buffer{}
+	assert.Nil(t, png.Encode(&buf, img))
+
+	tex, err := DecodeImageTexture(&buf)
+	assert.Nil(t, err)
+	assert.True(t, red.Equals(tex.UVColorAt(0.25, 0.5)))
+	assert.True(t, blue.Equals(tex.UVColorAt(0.75, 0.5)))
+}
+
+func TestDecodingAnUnknownImageFormat(t *testing.T) {
+	_, err := DecodeImageTexture(bytes.NewBufferString("not an image"))
+	assert.NotNil(t, err)
+}
+
+func TestAnEmptyImageTexture(t *testing.T) {
+	_, err := InitImageTexture(&viz.Canvas{})
+	assert.NotNil(t, err)
+}
+
+func TestLoadingAMissingImageTexture(t *testing.T) {
+	_, err := LoadImageTexture("does-not-exist.png")
+	assert.NotNil(t, err)
+}
+
+func TestDecodingAPPMImageTexture(t *testing.T) {
+	tex, err := DecodeImageTexture(bytes.NewBufferString("P3\n2 1\n255\n255 0 0  0 0 255\n"))
+	assert.Nil(t, err)
+	assert.True(t, red.Equals(tex.UVColorAt(0.25, 0.5)))
+	assert.True(t, blue.Equals(tex.UVColorAt(0.75, 0.5)))
+}
@@ -0,0 +1,41 @@
+package patterns
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+// UVMap converts a point in pattern space to texture coordinates.
+type UVMap func(p *tuples.Tuple) (u, v float64)
+
+// SphericalMap wraps a texture around a unit sphere, u runs around the y
+// axis and v from the south pole to the north.
+func SphericalMap(p *tuples.Tuple) (float64, float64) {
+	theta := math.Atan2(p.X, p.Z)
+	radius := tuples.InitVector(p.X, p.Y, p.Z).Magnitude()
+	// the center has no direction, treat it as on the equator
+	phi := math.Pi / 2
+	if radius > 0 {
+		phi = math.Acos(p.Y / radius)
+	}
+	u := 1 - (theta/(2*math.Pi) + 0.5)
+	v := 1 - phi/math.Pi
+	return u, v
+}
+
+// PlanarMap repeats a texture across every unit square of the xz plane.
+func PlanarMap(p *tuples.Tuple) (float64, float64) {
+	return fract(p.X), fract(p.Z)
+}
+
+// CylindricalMap wraps a texture around the y axis, repeating every unit
+// of height.
+func CylindricalMap(p *tuples.Tuple) (float64, float64) {
+	theta := math.Atan2(p.X, p.Z)
+	return 1 - (theta/(2*math.Pi) + 0.5), fract(p.Y)
+}
+
+func fract(x float64) float64 {
+	return x - math.Floor(x)
+}
@@ -0,0 +1,65 @@
+package patterns
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+type mappingOpt struct {
+	p *tuples.Tuple
+	u float64
+	v float64
+}
+
+func assertMapping(t *testing.T, m UVMap, opts []mappingOpt) {
+	for _, o := range opts {
+		u, v := m(o.p)
+		assert.True(t, maths.FuzzyEquals(o.u, u), "u at %v: %v", o.p, u)
+		assert.True(t, maths.FuzzyEquals(o.v, v), "v at %v: %v", o.p, v)
+	}
+}
+
+func TestUsingASphericalMappingOnA3DPoint(t *testing.T) {
+	assertMapping(t, SphericalMap, []mappingOpt{
+		{tuples.InitPoint(0, 0, -1), 0, 0.5},
+		{tuples.InitPoint(1, 0, 0), 0.25, 0.5},
+		{tuples.InitPoint(0, 0, 1), 0.5, 0.5},
+		{tuples.InitPoint(-1, 0, 0), 0.75, 0.5},
+		{tuples.InitPoint(0, 1, 0), 0.5, 1},
+		{tuples.InitPoint(0, -1, 0), 0.5, 0},
+		{tuples.InitPoint(math.Sqrt2/2, math.Sqrt2/2, 0), 0.25, 0.75},
+		{tuples.InitPoint(0, 0, 0), 0.5, 0.5},
+	})
+}
+
+func TestUsingAPlanarMappingOnA3DPoint(t *testing.T) {
+	assertMapping(t, PlanarMap, []mappingOpt{
+		{tuples.InitPoint(0.25, 0, 0.5), 0.25, 0.5},
+		{tuples.InitPoint(0.25, 0, -0.25), 0.25, 0.75},
+		{tuples.InitPoint(0.25, 0.5, -0.25), 0.25, 0.75},
+		{tuples.InitPoint(1.25, 0, 0.5), 0.25, 0.5},
+		{tuples.InitPoint(0.25, 0, -1.75), 0.25, 0.25},
+		{tuples.InitPoint(1, 0, -1), 0, 0},
+		{tuples.InitPoint(0, 0, 0), 0, 0},
+	})
+}
+
+func TestUsingACylindricalMappingOnA3DPoint(t *testing.T) {
+	h := math.Sqrt2 / 2
+	assertMapping(t, CylindricalMap, []mappingOpt{
+		{tuples.InitPoint(0, 0, -1), 0, 0},
+		{tuples.InitPoint(0, 0.5, -1), 0, 0.5},
+		{tuples.InitPoint(0, 1, -1), 0, 0},
+		{tuples.InitPoint(h, 0.5, -h), 0.125, 0.5},
+		{tuples.InitPoint(1, 0.5, 0), 0.25, 0.5},
+		{tuples.InitPoint(h, 0.5, h), 0.375, 0.5},
+		{tuples.InitPoint(0, -0.25, 1), 0.5, 0.75},
+		{tuples.InitPoint(-h, 0.5, h), 0.625, 0.5},
+		{tuples.InitPoint(-1, 1.25, 0), 0.75, 0.25},
+		{tuples.InitPoint(-h, 0.5, -h), 0.875, 0.5},
+	})
+}
@@ -0,0 +1,60 @@
+package patterns
+
+import (
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// Pattern colors points in its own space, which is nested inside the object
+// space of the shape it's applied to by the pattern's transform.
+type Pattern interface {
+	ColorAt(p *tuples.Tuple) *viz.Color
+	Transform() *matrix.Matrix
+	TransformInverse() *matrix.Matrix
+	SetTransform(t *matrix.Matrix)
+}
+
+type PatternEmbed struct {
+	transform        *matrix.Matrix
+	transformInverse *matrix.Matrix
+}
+
+func InitPatternEmbed() *PatternEmbed {
+	return &PatternEmbed{
+		matrix.InitMatrixIdentity(4),
+		matrix.InitMatrixIdentity(4),
+	}
+}
+
+func (p *PatternEmbed) Transform() *matrix.Matrix {
+	return p.transform
+}
+
+func (p *PatternEmbed) TransformInverse() *matrix.Matrix {
+	return p.transformInverse
+}
+
+func (p *PatternEmbed) SetTransform(t *matrix.Matrix) {
+	p.transform = t
+	p.transformInverse = t.Inverse()
+}
+
+// At converts an object space point into the pattern's space and colors it.
+func At(p Pattern, objectPoint *tuples.Tuple) *viz.Color {
+	return p.ColorAt(p.TransformInverse().MultiplyTuple(objectPoint))
+}
+
+// Solid is a single color everywhere, used to treat colors as patterns.
+type Solid struct {
+	*PatternEmbed
+	Color *viz.Color
+}
+
+func InitSolidPattern(c *viz.Color) *Solid {
+	return &Solid{InitPatternEmbed(), c}
+}
+
+func (s *Solid) ColorAt(p *tuples.Tuple) *viz.Color {
+	return s.Color
+}
@@ -0,0 +1,45 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+var black = viz.InitColor(0, 0, 0)
+var white = viz.InitColor(1, 1, 1)
+
+// testPattern colors each point with its own coordinates.
+type testPattern struct {
+	*PatternEmbed
+}
+
+func initTestPattern() *testPattern {
+	return &testPattern{InitPatternEmbed()}
+}
+
+func (p *testPattern) ColorAt(pt *tuples.Tuple) *viz.Color {
+	return viz.InitColor(pt.X, pt.Y, pt.Z)
+}
+
+func TestTheDefaultPatternTransformation(t *testing.T) {
+	p := initTestPattern()
+	assert.True(t, matrix.InitMatrixIdentity(4).Equals(p.Transform()))
+}
+
+func TestAssigningAPatternTransformation(t *testing.T) {
+	p := initTestPattern()
+	p.SetTransform(matrix.Translation(1, 2, 3))
+	assert.True(t, matrix.Translation(1, 2, 3).Equals(p.Transform()))
+	assert.True(t, matrix.Translation(-1, -2, -3).Equals(p.TransformInverse()))
+}
+
+func TestAPatternWithAPatternTransformation(t *testing.T) {
+	p := initTestPattern()
+	p.SetTransform(matrix.Scaling(2, 2, 2))
+	c := At(p, tuples.InitPoint(2, 3, 4))
+	assert.True(t, viz.InitColor(1, 1.5, 2).Equals(c))
+}
@@ -0,0 +1,77 @@
+package patterns
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Perlin is 3D gradient noise. The permutation table is shuffled from a seed
+// so the same seed always produces the same noise.
+type Perlin struct {
+	perm [512]int
+}
+
+func InitPerlin(seed int64) *Perlin {
+	n := &Perlin{}
+	r := rand.New(rand.NewSource(seed))
+	for i, v := range r.Perm(256) {
+		n.perm[i] = v
+		n.perm[i+256] = v
+	}
+	return n
+}
+
+// Noise returns a value in roughly -1..1 that varies smoothly with the point
+// and is 0 at integer coordinates.
+func (n *Perlin) Noise(x, y, z float64) float64 {
+	fx, fy, fz := math.Floor(x), math.Floor(y), math.Floor(z)
+	xi, yi, zi := int(fx)&255, int(fy)&255, int(fz)&255
+	x, y, z = x-fx, y-fy, z-fz
+	u, v, w := fade(x), fade(y), fade(z)
+
+	p := n.perm
+	a := p[xi] + yi
+	aa := p[a] + zi
+	ab := p[a+1] + zi
+	b := p[xi+1] + yi
+	ba := p[b] + zi
+	bb := p[b+1] + zi
+
+	return lerp(w,
+		lerp(v,
+			lerp(u, grad(p[aa], x, y, z), grad(p[ba], x-1, y, z)),
+			lerp(u, grad(p[ab], x, y-1, z), grad(p[bb], x-1, y-1, z))),
+		lerp(v,
+			lerp(u, grad(p[aa+1], x, y, z-1), grad(p[ba+1], x-1, y, z-1)),
+			lerp(u, grad(p[ab+1], x, y-1, z-1), grad(p[bb+1], x-1, y-1, z-1))))
+}
+
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+// grad dots the offset with one of 12 gradient directions picked by hash.
+func grad(hash int, x, y, z float64) float64 {
+	h := hash & 15
+	u := y
+	if h < 8 {
+		u = x
+	}
+	v := z
+	if h < 4 {
+		v = y
+	} else if h == 12 || h == 14 {
+		v = x
+	}
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return u + v
+}
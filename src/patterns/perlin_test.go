@@ -0,0 +1,38 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/maths"
+)
+
+func TestPerlinNoiseIsZeroAtLatticePoints(t *testing.T) {
+	n := InitPerlin(1)
+	for _, v := range []float64{-3, 0, 1, 7} {
+		assert.Equal(t, 0.0, n.Noise(v, v, v))
+	}
+}
+
+func TestPerlinNoiseIsDeterministicGivenASeed(t *testing.T) {
+	a := InitPerlin(42)
+	b := InitPerlin(42)
+	c := InitPerlin(43)
+	different := false
+	for i := 0; i < 20; i++ {
+		x, y, z := float64(i)*0.37, float64(i)*0.71, float64(i)*0.13
+		assert.Equal(t, a.Noise(x, y, z), b.Noise(x, y, z))
+		if !maths.FuzzyEquals(a.Noise(x, y, z), c.Noise(x, y, z)) {
+			different = true
+		}
+	}
+	assert.True(t, different)
+}
+
+func TestPerlinNoiseStaysInRange(t *testing.T) {
+	n := InitPerlin(7)
+	for i := 0; i < 1000; i++ {
+		v := n.Noise(float64(i)*0.123, float64(i)*-0.457, float64(i)*0.789)
+		assert.True(t, v >= -1 && v <= 1)
+	}
+}
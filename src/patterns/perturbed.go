@@ -0,0 +1,30 @@
+package patterns
+
+import (
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// Perturbed jitters each point with Perlin noise before looking it up in the
+// wrapped pattern. Each coordinate moves by Scale times the noise, so
+// typically less than Scale though the noise can stray slightly past 1.
+type Perturbed struct {
+	*PatternEmbed
+	Pattern Pattern
+	Scale   float64
+	noise   *Perlin
+}
+
+func InitPerturbedPattern(p Pattern, scale float64, seed int64) *Perturbed {
+	return &Perturbed{InitPatternEmbed(), p, scale, InitPerlin(seed)}
+}
+
+func (pp *Perturbed) ColorAt(p *tuples.Tuple) *viz.Color {
+	// Offsetting z samples an independent looking noise value per axis.
+	moved := tuples.InitPoint(
+		p.X+pp.Scale*pp.noise.Noise(p.X, p.Y, p.Z),
+		p.Y+pp.Scale*pp.noise.Noise(p.X, p.Y, p.Z+1.5),
+		p.Z+pp.Scale*pp.noise.Noise(p.X, p.Y, p.Z+3.5),
+	)
+	return At(pp.Pattern, moved)
+}
@@ -0,0 +1,31 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+func TestAPerturbedPatternWithNoScaleMatchesItsPattern(t *testing.T) {
+	s := InitStripePattern(white, black)
+	p := InitPerturbedPattern(s, 0, 1)
+	for _, x := range []float64{-0.5, 0.25, 0.75, 1.5} {
+		pt := tuples.InitPoint(x, 0.3, 0.6)
+		assert.True(t, s.ColorAt(pt).Equals(p.ColorAt(pt)))
+	}
+}
+
+func TestAPerturbedPatternMovesLookups(t *testing.T) {
+	p := InitPerturbedPattern(initTestPattern(), 0.5, 1)
+	pt := tuples.InitPoint(0.3, 0.6, 0.2)
+	assert.False(t, viz.InitColor(0.3, 0.6, 0.2).Equals(p.ColorAt(pt)))
+}
+
+func TestPerturbedPatternsAreDeterministicGivenASeed(t *testing.T) {
+	a := InitPerturbedPattern(initTestPattern(), 0.5, 9)
+	b := InitPerturbedPattern(initTestPattern(), 0.5, 9)
+	pt := tuples.InitPoint(1.3, -0.6, 2.2)
+	assert.True(t, a.ColorAt(pt).Equals(b.ColorAt(pt)))
+}
@@ -0,0 +1,32 @@
+package patterns
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// Ring alternates between A and B in unit wide rings around the y axis.
+type Ring struct {
+	*PatternEmbed
+	A Pattern
+	B Pattern
+}
+
+func InitRingPattern(a, b *viz.Color) *Ring {
+	return InitRingPatternOf(InitSolidPattern(a), InitSolidPattern(b))
+}
+
+// InitRingPatternOf uses patterns in place of A and B, each nested in
+// this pattern's space.
+func InitRingPatternOf(a, b Pattern) *Ring {
+	return &Ring{InitPatternEmbed(), a, b}
+}
+
+func (r *Ring) ColorAt(p *tuples.Tuple) *viz.Color {
+	if math.Mod(math.Floor(math.Sqrt(math.Pow(p.X, 2)+math.Pow(p.Z, 2))), 2) == 0 {
+		return At(r.A, p)
+	}
+	return At(r.B, p)
+}
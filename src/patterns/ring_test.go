@@ -0,0 +1,17 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+func TestARingShouldExtendInBothXAndZ(t *testing.T) {
+	p := InitRingPattern(white, black)
+	assert.True(t, white.Equals(p.ColorAt(tuples.InitPoint(0, 0, 0))))
+	assert.True(t, black.Equals(p.ColorAt(tuples.InitPoint(1, 0, 0))))
+	assert.True(t, black.Equals(p.ColorAt(tuples.InitPoint(0, 0, 1))))
+	// 0.708 = just slightly more than sqrt(2)/2
+	assert.True(t, black.Equals(p.ColorAt(tuples.InitPoint(0.708, 0, 0.708))))
+}
@@ -0,0 +1,32 @@
+package patterns
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// Stripe alternates between A and B every unit along x.
+type Stripe struct {
+	*PatternEmbed
+	A Pattern
+	B Pattern
+}
+
+func InitStripePattern(a, b *viz.Color) *Stripe {
+	return InitStripePatternOf(InitSolidPattern(a), InitSolidPattern(b))
+}
+
+// InitStripePatternOf uses patterns in place of A and B, each nested in
+// this pattern's space.
+func InitStripePatternOf(a, b Pattern) *Stripe {
+	return &Stripe{InitPatternEmbed(), a, b}
+}
+
+func (s *Stripe) ColorAt(p *tuples.Tuple) *viz.Color {
+	if math.Mod(math.Floor(p.X), 2) == 0 {
+		return At(s.A, p)
+	}
+	return At(s.B, p)
+}
@@ -0,0 +1,48 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+func TestCreatingAStripePattern(t *testing.T) {
+	p := InitStripePattern(white, black)
+	assert.True(t, white.Equals(p.A.(*Solid).Color))
+	assert.True(t, black.Equals(p.B.(*Solid).Color))
+}
+
+func TestAStripePatternIsConstantInYAndZ(t *testing.T) {
+	p := InitStripePattern(white, black)
+	for _, pt := range []*tuples.Tuple{
+		tuples.InitPoint(0, 0, 0),
+		tuples.InitPoint(0, 1, 0),
+		tuples.InitPoint(0, 2, 0),
+		tuples.InitPoint(0, 0, 1),
+		tuples.InitPoint(0, 0, 2),
+	} {
+		assert.True(t, white.Equals(p.ColorAt(pt)), pt)
+	}
+}
+
+func TestAStripePatternAlternatesInX(t *testing.T) {
+	type opt struct {
+		x   float64
+		exp bool
+	}
+	p := InitStripePattern(white, black)
+	for _, o := range []opt{{0, true}, {0.9, true}, {1, false}, {-0.1, false}, {-1, false}, {-1.1, true}} {
+		c := p.ColorAt(tuples.InitPoint(o.x, 0, 0))
+		assert.Equal(t, o.exp, white.Equals(c), o.x)
+	}
+}
+
+func TestStripesWithATransformation(t *testing.T) {
+	p := InitStripePattern(white, black)
+	p.SetTransform(matrix.Scaling(2, 2, 2))
+	assert.True(t, white.Equals(At(p, tuples.InitPoint(1.5, 0, 0))))
+	p.SetTransform(matrix.Translation(0.5, 0, 0))
+	assert.True(t, white.Equals(At(p, tuples.InitPoint(1.2, 0, 0))))
+}
@@ -0,0 +1,21 @@
+package patterns
+
+import (
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// TextureMap applies a 2D texture to a shape through a UV mapping.
+type TextureMap struct {
+	*PatternEmbed
+	UV      UVPattern
+	Mapping UVMap
+}
+
+func InitTextureMapPattern(uv UVPattern, mapping UVMap) *TextureMap {
+	return &TextureMap{InitPatternEmbed(), uv, mapping}
+}
+
+func (t *TextureMap) ColorAt(p *tuples.Tuple) *viz.Color {
+	return t.UV.UVColorAt(t.Mapping(p))
+}
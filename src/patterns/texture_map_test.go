@@ -0,0 +1,32 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+func TestUsingATextureMapPatternWithASphericalMap(t *testing.T) {
+	type opt struct {
+		p   *tuples.Tuple
+		exp *viz.Color
+	}
+	opts := []opt{
+		{tuples.InitPoint(0.4315, 0.4670, 0.7719), white},
+		{tuples.InitPoint(-0.9654, 0.2552, -0.0534), black},
+		{tuples.InitPoint(0.1039, 0.7090, 0.6975), white},
+		{tuples.InitPoint(-0.4986, -0.7856, -0.3663), black},
+		{tuples.InitPoint(-0.0317, -0.9395, 0.3411), black},
+		{tuples.InitPoint(0.4809, -0.7721, 0.4154), black},
+		{tuples.InitPoint(0.0285, -0.9612, -0.2745), black},
+		{tuples.InitPoint(-0.5734, -0.2162, -0.7903), white},
+		{tuples.InitPoint(0.7688, -0.1470, 0.6223), black},
+		{tuples.InitPoint(-0.7652, 0.2175, 0.6060), black},
+	}
+	p := InitTextureMapPattern(InitUVCheckersPattern(16, 8, black, white), SphericalMap)
+	for _, o := range opts {
+		assert.True(t, o.exp.Equals(p.ColorAt(o.p)), "%v", o.p)
+	}
+}
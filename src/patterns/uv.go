@@ -0,0 +1,73 @@
+package patterns
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// UVPattern colors a 2D texture, u and v run from 0 to 1 across it.
+type UVPattern interface {
+	UVColorAt(u, v float64) *viz.Color
+}
+
+// UVCheckers has Width by Height squares alternating between A and B.
+type UVCheckers struct {
+	Width  float64
+	Height float64
+	A      *viz.Color
+	B      *viz.Color
+}
+
+func InitUVCheckersPattern(width, height float64, a, b *viz.Color) *UVCheckers {
+	return &UVCheckers{width, height, a, b}
+}
+
+func (c *UVCheckers) UVColorAt(u, v float64) *viz.Color {
+	if math.Mod(math.Floor(u*c.Width)+math.Floor(v*c.Height), 2) == 0 {
+		return c.A
+	}
+	return c.B
+}
+
+// UVAlignCheck is Main with a differently colored square in each corner,
+// useful for checking which way round a texture is mapped.
+type UVAlignCheck struct {
+	Main        *viz.Color
+	UpperLeft   *viz.Color
+	UpperRight  *viz.Color
+	BottomLeft  *viz.Color
+	BottomRight *viz.Color
+}
+
+func InitUVAlignCheckPattern(main, ul, ur, bl, br *viz.Color) *UVAlignCheck {
+	return &UVAlignCheck{main, ul, ur, bl, br}
+}
+
+func (a *UVAlignCheck) UVColorAt(u, v float64) *viz.Color {
+	switch {
+	case v > 0.8 && u < 0.2:
+		return a.UpperLeft
+	case v > 0.8 && u > 0.8:
+		return a.UpperRight
+	case v < 0.2 && u < 0.2:
+		return a.BottomLeft
+	case v < 0.2 && u > 0.8:
+		return a.BottomRight
+	}
+	return a.Main
+}
+
+// PatternUV uses any pattern as a texture by looking up (u, 0, v) in it.
+type PatternUV struct {
+	Pattern Pattern
+}
+
+func InitPatternUV(p Pattern) *PatternUV {
+	return &PatternUV{p}
+}
+
+func (p *PatternUV) UVColorAt(u, v float64) *viz.Color {
+	return At(p.Pattern, tuples.InitPoint(u, 0, v))
+}
@@ -0,0 +1,61 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+var red = viz.InitColor(1, 0, 0)
+var yellow = viz.InitColor(1, 1, 0)
+var brown = viz.InitColor(1, 0.5, 0)
+var green = viz.InitColor(0, 1, 0)
+var cyan = viz.InitColor(0, 1, 1)
+var blue = viz.InitColor(0, 0, 1)
+var purple = viz.InitColor(1, 0, 1)
+
+func TestCheckerPatternIn2D(t *testing.T) {
+	type opt struct {
+		u   float64
+		v   float64
+		exp *viz.Color
+	}
+	opts := []opt{
+		{0, 0, black},
+		{0.5, 0, white},
+		{0, 0.5, white},
+		{0.5, 0.5, black},
+		{1, 1, black},
+	}
+	c := InitUVCheckersPattern(2, 2, black, white)
+	for _, o := range opts {
+		assert.True(t, o.exp.Equals(c.UVColorAt(o.u, o.v)), "%v, %v", o.u, o.v)
+	}
+}
+
+func TestLayoutOfTheAlignCheckPattern(t *testing.T) {
+	type opt struct {
+		s   string
+		u   float64
+		v   float64
+		exp *viz.Color
+	}
+	opts := []opt{
+		{"main", 0.5, 0.5, white},
+		{"upper left", 0.1, 0.9, red},
+		{"upper right", 0.9, 0.9, yellow},
+		{"bottom left", 0.1, 0.1, green},
+		{"bottom right", 0.9, 0.1, cyan},
+	}
+	a := InitUVAlignCheckPattern(white, red, yellow, green, cyan)
+	for _, o := range opts {
+		assert.True(t, o.exp.Equals(a.UVColorAt(o.u, o.v)), o.s)
+	}
+}
+
+func TestAPatternUsedAsATexture(t *testing.T) {
+	p := InitPatternUV(InitStripePattern(white, black))
+	assert.True(t, white.Equals(p.UVColorAt(0.5, 0.5)))
+	assert.True(t, black.Equals(p.UVColorAt(1.5, 0.5)))
+}
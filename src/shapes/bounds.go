@@ -0,0 +1,124 @@
+package shapes
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+// Bounds is an axis aligned bounding box. Shapes report theirs in the space
+// of their parent (world space for top level objects).
+type Bounds struct {
+	Min *tuples.Tuple
+	Max *tuples.Tuple
+}
+
+func InitBounds(min, max *tuples.Tuple) *Bounds {
+	return &Bounds{min, max}
+}
+
+// EmptyBounds contains nothing, merging anything into it yields the other box.
+func EmptyBounds() *Bounds {
+	inf := math.Inf(1)
+	return &Bounds{tuples.InitPoint(inf, inf, inf), tuples.InitPoint(-inf, -inf, -inf)}
+}
+
+func InfiniteBounds() *Bounds {
+	inf := math.Inf(1)
+	return &Bounds{tuples.InitPoint(-inf, -inf, -inf), tuples.InitPoint(inf, inf, inf)}
+}
+
+func (b *Bounds) Equals(b2 *Bounds) bool {
+	return boundsEqual(b.Min, b2.Min) && boundsEqual(b.Max, b2.Max)
+}
+
+// boundsEqual compares corners allowing for infinite components, which
+// FuzzyEquals can't handle.
+func boundsEqual(p1, p2 *tuples.Tuple) bool {
+	for _, v := range [][2]float64{{p1.X, p2.X}, {p1.Y, p2.Y}, {p1.Z, p2.Z}} {
+		if v[0] != v[1] && !maths.FuzzyEquals(v[0], v[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *Bounds) IsFinite() bool {
+	for _, v := range []float64{b.Min.X, b.Min.Y, b.Min.Z, b.Max.X, b.Max.Y, b.Max.Z} {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *Bounds) AddPoint(p *tuples.Tuple) *Bounds {
+	return &Bounds{
+		tuples.InitPoint(math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y), math.Min(b.Min.Z, p.Z)),
+		tuples.InitPoint(math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y), math.Max(b.Max.Z, p.Z)),
+	}
+}
+
+func (b *Bounds) Merge(b2 *Bounds) *Bounds {
+	return b.AddPoint(b2.Min).AddPoint(b2.Max)
+}
+
+func (b *Bounds) Centroid() *tuples.Tuple {
+	return tuples.InitPoint((b.Min.X+b.Max.X)/2, (b.Min.Y+b.Max.Y)/2, (b.Min.Z+b.Max.Z)/2)
+}
+
+// Transform returns the box containing all eight transformed corners. Boxes
+// with infinite extents can't be transformed meaningfully and become infinite
+// in every direction.
+func (b *Bounds) Transform(m *matrix.Matrix) *Bounds {
+	if !b.IsFinite() {
+		return InfiniteBounds()
+	}
+	res := EmptyBounds()
+	for _, x := range []float64{b.Min.X, b.Max.X} {
+		for _, y := range []float64{b.Min.Y, b.Max.Y} {
+			for _, z := range []float64{b.Min.Z, b.Max.Z} {
+				res = res.AddPoint(m.MultiplyTuple(tuples.InitPoint(x, y, z)))
+			}
+		}
+	}
+	return res
+}
+
+// Intersects reports whether the line the ray travels along passes through
+// the box. Hits behind the ray origin count so callers see the same
+// intersections they would without culling.
+func (b *Bounds) Intersects(r *Ray) bool {
+	tmin := math.Inf(-1)
+	tmax := math.Inf(1)
+	for _, a := range [][4]float64{
+		{r.Origin.X, r.Direction.X, b.Min.X, b.Max.X},
+		{r.Origin.Y, r.Direction.Y, b.Min.Y, b.Max.Y},
+		{r.Origin.Z, r.Direction.Z, b.Min.Z, b.Max.Z},
+	} {
+		origin, direction, min, max := a[0], a[1], a[2], a[3]
+		if direction == 0 {
+			if origin < min || origin > max {
+				return false
+			}
+			continue
+		}
+		t0 := (min - origin) / direction
+		t1 := (max - origin) / direction
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		if t0 > tmin {
+			tmin = t0
+		}
+		if t1 < tmax {
+			tmax = t1
+		}
+		if tmin > tmax {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+func TestEmptyBoundsMergeToTheOtherBox(t *testing.T) {
+	b := InitBounds(tuples.InitPoint(-1, -2, -3), tuples.InitPoint(3, 2, 1))
+	assert.True(t, b.Equals(EmptyBounds().Merge(b)))
+}
+
+func TestAddingPointsToBounds(t *testing.T) {
+	b := EmptyBounds().AddPoint(tuples.InitPoint(-5, 2, 0)).AddPoint(tuples.InitPoint(7, 0, -3))
+	assert.True(t, tuples.InitPoint(-5, 0, -3).Equals(b.Min))
+	assert.True(t, tuples.InitPoint(7, 2, 0).Equals(b.Max))
+}
+
+func TestTransformingBounds(t *testing.T) {
+	b := InitBounds(tuples.InitPoint(-1, -1, -1), tuples.InitPoint(1, 1, 1))
+	res := b.Transform(matrix.Chain(matrix.RotationY(1.0/4.0), matrix.Translation(1, 0, 0)))
+	assert.True(t, tuples.InitPoint(1-math.Sqrt(2), -1, -math.Sqrt(2)).Equals(res.Min))
+	assert.True(t, tuples.InitPoint(1+math.Sqrt(2), 1, math.Sqrt(2)).Equals(res.Max))
+}
+
+func TestTransformingInfiniteBounds(t *testing.T) {
+	p := InitPlane()
+	p.SetTransform(matrix.Translation(0, 1, 0))
+	assert.False(t, p.Bounds().IsFinite())
+	assert.True(t, InfiniteBounds().Equals(p.Bounds()))
+}
+
+func TestSphereBounds(t *testing.T) {
+	s := InitSphere()
+	s.SetTransform(matrix.Chain(matrix.Scaling(2, 2, 2), matrix.Translation(0, 3, 0)))
+	b := s.Bounds()
+	assert.True(t, tuples.InitPoint(-2, 1, -2).Equals(b.Min))
+	assert.True(t, tuples.InitPoint(2, 5, 2).Equals(b.Max))
+}
+
+func TestRayIntersectsBounds(t *testing.T) {
+	type opt struct {
+		s   string
+		r   *Ray
+		exp bool
+	}
+	opts := []opt{
+		{
+			s:   "A ray through the middle of the box",
+			r:   InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1)),
+			exp: true,
+		},
+		{
+			s:   "A diagonal ray through the box",
+			r:   InitRay(tuples.InitPoint(-5, -5, -5), tuples.InitVector(1, 1, 1).Normalize()),
+			exp: true,
+		},
+		{
+			s:   "A ray passing beside the box",
+			r:   InitRay(tuples.InitPoint(2, 0, -5), tuples.InitVector(0, 0, 1)),
+			exp: false,
+		},
+		{
+			s:   "A ray pointing away from the box still passes through its line",
+			r:   InitRay(tuples.InitPoint(0, 0, 5), tuples.InitVector(0, 0, 1)),
+			exp: true,
+		},
+		{
+			s:   "A diagonal ray missing the corner",
+			r:   InitRay(tuples.InitPoint(-2, 0, -2), tuples.InitVector(1, 0, -1).Normalize()),
+			exp: false,
+		},
+	}
+	b := InitBounds(tuples.InitPoint(-1, -1, -1), tuples.InitPoint(1, 1, 1))
+	for _, o := range opts {
+		assert.Equal(t, o.exp, b.Intersects(o.r), o.s)
+	}
+}
@@ -0,0 +1,198 @@
+package shapes
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// bvhLeafSize is the most shapes a node holds before it is split.
+const bvhLeafSize = 4
+
+// BVH is a bounding volume hierarchy over a set of shapes. Rays only test
+// shapes whose bounds they pass through. Shapes without finite bounds (e.g.
+// planes) can't be placed in the tree and are tested against every ray.
+type BVH struct {
+	root      *bvhNode
+	unbounded []Shape
+}
+
+type bvhNode struct {
+	bounds *Bounds
+	left   *bvhNode
+	right  *bvhNode
+	shapes []Shape
+}
+
+type bvhEntry struct {
+	shape    Shape
+	bounds   *Bounds
+	centroid float64
+}
+
+func InitBVH(ss []Shape) *BVH {
+	b := &BVH{}
+	entries := []*bvhEntry{}
+	for _, s := range ss {
+		bounds := s.Bounds()
+		if !bounds.IsFinite() {
+			b.unbounded = append(b.unbounded, s)
+			continue
+		}
+		entries = append(entries, &bvhEntry{shape: s, bounds: bounds})
+	}
+	if len(entries) > 0 {
+		b.root = buildBVHNode(entries)
+	}
+	return b
+}
+
+// buildBVHNode splits entries at the median centroid along the axis where the
+// centroids are most spread out.
+func buildBVHNode(entries []*bvhEntry) *bvhNode {
+	n := &bvhNode{bounds: EmptyBounds()}
+	centroids := EmptyBounds()
+	for _, e := range entries {
+		n.bounds = n.bounds.Merge(e.bounds)
+		centroids = centroids.AddPoint(e.bounds.Centroid())
+	}
+	if len(entries) <= bvhLeafSize {
+		for _, e := range entries {
+			n.shapes = append(n.shapes, e.shape)
+		}
+		return n
+	}
+
+	extent := centroids.Max.Subtract(centroids.Min)
+	for _, e := range entries {
+		c := e.bounds.Centroid()
+		switch {
+		case extent.X >= extent.Y && extent.X >= extent.Z:
+			e.centroid = c.X
+		case extent.Y >= extent.Z:
+			e.centroid = c.Y
+		default:
+			e.centroid = c.Z
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].centroid < entries[j].centroid
+	})
+	mid := len(entries) / 2
+	n.left = buildBVHNode(entries[:mid])
+	n.right = buildBVHNode(entries[mid:])
+	return n
+}
+
+func (b *BVH) Intersect(r *Ray) *Intersections {
+	xs := []*Intersection{}
+	for _, s := range b.unbounded {
+		xs = append(xs, s.Intersect(r).Intersections...)
+	}
+	if b.root != nil {
+		xs = b.root.intersect(r, xs)
+	}
+	return InitIntersections(xs...)
+}
+
+func (n *bvhNode) intersect(r *Ray, xs []*Intersection) []*Intersection {
+	if !n.bounds.Intersects(r) {
+		return xs
+	}
+	for _, s := range n.shapes {
+		xs = append(xs, s.Intersect(r).Intersections...)
+	}
+	if n.left != nil {
+		xs = n.left.intersect(r, xs)
+	}
+	if n.right != nil {
+		xs = n.right.intersect(r, xs)
+	}
+	return xs
+}
+
+// BVHCache holds a BVH over a list of shapes and rebuilds it when the list is
+// reassigned or resized, after Invalidate, or when a shape in the list without
+// a parent is invalidated, e.g. by SetTransform. Replacing an element of the
+// list in place needs a Rebuild.
+type BVHCache struct {
+	current atomic.Pointer[cachedBVH]
+	dirty   atomic.Bool
+	lock    sync.Mutex
+}
+
+type cachedBVH struct {
+	bvh    *BVH
+	shapes []Shape
+	// watched is copied from shapes as the list may be edited in place
+	watched []Shape
+}
+
+func (c *cachedBVH) stale(ss []Shape) bool {
+	if len(c.shapes) != len(ss) {
+		return true
+	}
+	return len(ss) > 0 && &c.shapes[0] != &ss[0]
+}
+
+func (c *BVHCache) Get(ss []Shape) *BVH {
+	if cur := c.current.Load(); cur != nil && !c.dirty.Load() && !cur.stale(ss) {
+		return cur.bvh
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if cur := c.current.Load(); cur != nil && !c.dirty.Load() && !cur.stale(ss) {
+		return cur.bvh
+	}
+	return c.rebuild(ss)
+}
+
+// Invalidate marks the BVH stale so the next Get rebuilds it.
+func (c *BVHCache) Invalidate() {
+	c.dirty.Store(true)
+}
+
+// Rebuild rebuilds the BVH along with those of any groups in the list.
+func (c *BVHCache) Rebuild(ss []Shape) *BVH {
+	for _, s := range ss {
+		invalidateTree(s)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.rebuild(ss)
+}
+
+func (c *BVHCache) rebuild(ss []Shape) *BVH {
+	if old := c.current.Load(); old != nil {
+		for _, s := range old.watched {
+			s.unwatch(c)
+		}
+	}
+	cur := &cachedBVH{shapes: ss}
+	for _, s := range ss {
+		// a group's children reach its BVH through their parent instead
+		if s.Parent() == nil {
+			s.watch(c)
+			cur.watched = append(cur.watched, s)
+		}
+	}
+	// cleared after watching so a change made meanwhile isn't lost
+	c.dirty.Store(false)
+	cur.bvh = InitBVH(ss)
+	c.current.Store(cur)
+	return cur.bvh
+}
+
+// invalidateTree marks the BVHs of s and every group nested inside it stale.
+func invalidateTree(s Shape) {
+	switch v := s.(type) {
+	case *Group:
+		v.bvh.Invalidate()
+		for _, c := range v.Children {
+			invalidateTree(c)
+		}
+	case *CSG:
+		invalidateTree(v.Left)
+		invalidateTree(v.Right)
+	}
+}
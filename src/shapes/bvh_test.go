@@ -0,0 +1,46 @@
+package shapes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+func TestBVHFindsTheSameIntersectionsAsTestingEveryShape(t *testing.T) {
+	ss := []Shape{InitPlane()}
+	for x := -5; x <= 5; x++ {
+		for y := -5; y <= 5; y++ {
+			s := InitSphere()
+			s.SetTransform(matrix.Chain(matrix.Scaling(0.4, 0.4, 0.4), matrix.Translation(float64(x), float64(y), 0)))
+			ss = append(ss, s)
+		}
+	}
+	b := InitBVH(ss)
+
+	for _, r := range []*Ray{
+		InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1)),
+		InitRay(tuples.InitPoint(-6, -6, -1), tuples.InitVector(1, 1, 0.1).Normalize()),
+		InitRay(tuples.InitPoint(3.2, -2.1, -5), tuples.InitVector(0, 0.2, 1).Normalize()),
+		InitRay(tuples.InitPoint(0.5, 0.5, -5), tuples.InitVector(0, 0, 1)),
+	} {
+		all := []*Intersection{}
+		for _, s := range ss {
+			all = append(all, s.Intersect(r).Intersections...)
+		}
+		exp := InitIntersections(all...)
+		xs := b.Intersect(r)
+		assert.Equal(t, len(exp.Intersections), len(xs.Intersections))
+		for i := range exp.Intersections {
+			assert.True(t, exp.Intersections[i].Equals(xs.Intersections[i]))
+		}
+	}
+}
+
+func TestEmptyBVH(t *testing.T) {
+	b := InitBVH([]Shape{})
+	xs := b.Intersect(InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1)))
+	assert.Empty(t, xs.Intersections)
+	assert.Nil(t, xs.Hit())
+}
@@ -0,0 +1,96 @@
+package shapes
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+// Cone is a double napped cone around the y axis with its tip at the origin
+// and radius equal to |y|. Like Cylinder it is truncated between Minimum and
+// Maximum and capped when Closed.
+type Cone struct {
+	*ShapeEmbed
+	Minimum float64
+	Maximum float64
+	Closed  bool
+}
+
+func InitCone() *Cone {
+	return &Cone{
+		InitShapeEmbed(nil, nil),
+		math.Inf(-1),
+		math.Inf(1),
+		false,
+	}
+}
+
+func (s Cone) Intersect(r *Ray) *Intersections {
+	r = s.prepIntersect(r)
+	xs := InitIntersections()
+
+	a := math.Pow(r.Direction.X, 2) - math.Pow(r.Direction.Y, 2) + math.Pow(r.Direction.Z, 2)
+	b := 2*r.Origin.X*r.Direction.X - 2*r.Origin.Y*r.Direction.Y + 2*r.Origin.Z*r.Direction.Z
+	c := math.Pow(r.Origin.X, 2) - math.Pow(r.Origin.Y, 2) + math.Pow(r.Origin.Z, 2)
+
+	ts := []float64{}
+	if maths.FuzzyEquals(a, 0) {
+		// the ray is parallel to one of the cone's halves and hits the
+		// other once, if at all
+		if !maths.FuzzyEquals(b, 0) {
+			ts = append(ts, -c/(2*b))
+		}
+	} else {
+		d := math.Pow(b, 2) - 4*a*c
+		if d >= 0 {
+			ts = append(ts, (-b-math.Sqrt(d))/(2*a), (-b+math.Sqrt(d))/(2*a))
+		}
+	}
+	for _, t := range ts {
+		y := r.Origin.Y + t*r.Direction.Y
+		if s.Minimum < y && y < s.Maximum {
+			xs.Add(InitIntersection(t, s))
+		}
+	}
+
+	if s.Closed {
+		intersectCaps(r, s, s.Minimum, s.Maximum, math.Abs, xs)
+	}
+	return xs
+}
+
+func (s Cone) Equals(s2 any) bool {
+	if v, ok := s2.(Cone); ok {
+		return s.Id == v.Id
+	}
+	if v, ok := s2.(*Cone); ok {
+		return s.Id == v.Id
+	}
+	return false
+}
+
+func (s Cone) Clone() Shape {
+	s.ShapeEmbed = s.ShapeEmbed.clone()
+	return &s
+}
+
+func (s Cone) NormalAt(p *tuples.Tuple) *tuples.Tuple {
+	lp := s.normalAtPre(p)
+	dist := math.Pow(lp.X, 2) + math.Pow(lp.Z, 2)
+	if dist < math.Pow(s.Maximum, 2) && lp.Y >= s.Maximum-maths.EPSILON {
+		return s.normalAtPost(tuples.InitVector(0, 1, 0))
+	} else if dist < math.Pow(s.Minimum, 2) && lp.Y <= s.Minimum+maths.EPSILON {
+		return s.normalAtPost(tuples.InitVector(0, -1, 0))
+	}
+	y := math.Sqrt(dist)
+	if lp.Y > 0 {
+		y = -y
+	}
+	return s.normalAtPost(tuples.InitVector(lp.X, y, lp.Z))
+}
+
+func (s Cone) Bounds() *Bounds {
+	limit := math.Max(math.Abs(s.Minimum), math.Abs(s.Maximum))
+	return s.parentSpaceBounds(InitBounds(tuples.InitPoint(-limit, s.Minimum, -limit), tuples.InitPoint(limit, s.Maximum, limit)))
+}
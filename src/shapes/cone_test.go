@@ -0,0 +1,90 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+func TestIntersectingAConeWithARay(t *testing.T) {
+	type opt struct {
+		r  *Ray
+		t0 float64
+		t1 float64
+	}
+	opts := []opt{
+		{r: InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1)), t0: 5, t1: 5},
+		{r: InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(1, 1, 1).Normalize()), t0: 8.66025, t1: 8.66025},
+		{r: InitRay(tuples.InitPoint(1, 1, -5), tuples.InitVector(-0.5, -1, 1).Normalize()), t0: 4.55006, t1: 49.44994},
+	}
+	for _, o := range opts {
+		c := InitCone()
+		xs := c.Intersect(o.r)
+		assert.Equal(t, 2, len(xs.Intersections))
+		assert.True(t, maths.FuzzyEquals(o.t0, xs.Intersections[0].T), xs.Intersections[0].T)
+		assert.True(t, maths.FuzzyEquals(o.t1, xs.Intersections[1].T), xs.Intersections[1].T)
+	}
+}
+
+func TestIntersectingAConeWithARayParallelToOneOfItsHalves(t *testing.T) {
+	c := InitCone()
+	r := InitRay(tuples.InitPoint(0, 0, -1), tuples.InitVector(0, 1, 1).Normalize())
+	xs := c.Intersect(r)
+	assert.Equal(t, 1, len(xs.Intersections))
+	assert.True(t, maths.FuzzyEquals(0.35355, xs.Intersections[0].T))
+}
+
+func TestIntersectingTheCapsOfAClosedCone(t *testing.T) {
+	type opt struct {
+		r     *Ray
+		count int
+	}
+	opts := []opt{
+		{r: InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 1, 0)), count: 0},
+		{r: InitRay(tuples.InitPoint(0, 0, -0.25), tuples.InitVector(0, 1, 1).Normalize()), count: 2},
+		{r: InitRay(tuples.InitPoint(0, 0, -0.25), tuples.InitVector(0, 1, 0)), count: 4},
+	}
+	for _, o := range opts {
+		c := InitCone()
+		c.Minimum = -0.5
+		c.Maximum = 0.5
+		c.Closed = true
+		assert.Equal(t, o.count, len(c.Intersect(o.r).Intersections), o.r.Direction)
+	}
+}
+
+func TestNormalOnACone(t *testing.T) {
+	type opt struct {
+		p *tuples.Tuple
+		v *tuples.Tuple
+	}
+	opts := []opt{
+		{p: tuples.InitPoint(1, 1, 1), v: tuples.InitVector(1, -math.Sqrt(2), 1).Normalize()},
+		{p: tuples.InitPoint(-1, -1, 0), v: tuples.InitVector(-1, 1, 0).Normalize()},
+	}
+	for _, o := range opts {
+		c := InitCone()
+		assert.True(t, o.v.Equals(c.NormalAt(o.p)), o.p)
+	}
+}
+
+func TestNormalOnTheCapsOfACone(t *testing.T) {
+	c := InitCone()
+	c.Minimum = -1
+	c.Maximum = 2
+	c.Closed = true
+	assert.True(t, tuples.InitVector(0, 1, 0).Equals(c.NormalAt(tuples.InitPoint(0.5, 2, 0.5))))
+	assert.True(t, tuples.InitVector(0, -1, 0).Equals(c.NormalAt(tuples.InitPoint(0.2, -1, 0.3))))
+}
+
+func TestTruncatedConeBounds(t *testing.T) {
+	c := InitCone()
+	c.Minimum = -5
+	c.Maximum = 3
+	b := c.Bounds()
+	assert.True(t, tuples.InitPoint(-5, -5, -5).Equals(b.Min))
+	assert.True(t, tuples.InitPoint(5, 3, 5).Equals(b.Max))
+}
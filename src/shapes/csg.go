@@ -0,0 +1,117 @@
+package shapes
+
+import (
+	"log"
+
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+type CSGOperation int
+
+const (
+	CSGUnion CSGOperation = iota
+	CSGIntersection
+	CSGDifference
+)
+
+// CSG combines two shapes with constructive solid geometry, keeping only the
+// surfaces the operation calls for.
+type CSG struct {
+	*ShapeEmbed
+	Operation CSGOperation
+	Left      Shape
+	Right     Shape
+}
+
+// InitCSG errors if either shape already has a parent.
+func InitCSG(op CSGOperation, l, r Shape) (*CSG, error) {
+	if err := checkOrphans(l, r); err != nil {
+		return nil, err
+	}
+	c := &CSG{InitShapeEmbed(nil, nil), op, l, r}
+	l.SetParent(c)
+	r.SetParent(c)
+	return c, nil
+}
+
+// IntersectionAllowed decides whether a hit on the left (lhit) or right
+// shape survives the operation, given whether the hit is inside the left
+// (inl) and right (inr) shapes.
+func IntersectionAllowed(op CSGOperation, lhit, inl, inr bool) bool {
+	switch op {
+	case CSGUnion:
+		return (lhit && !inr) || (!lhit && !inl)
+	case CSGIntersection:
+		return (lhit && inr) || (!lhit && inl)
+	case CSGDifference:
+		return (lhit && !inr) || (!lhit && inl)
+	}
+	return false
+}
+
+// FilterIntersections walks the sorted intersections tracking whether each
+// is inside the left and right shapes and keeps the allowed ones.
+func (c *CSG) FilterIntersections(xs *Intersections) *Intersections {
+	inl := false
+	inr := false
+	res := []*Intersection{}
+	for _, i := range xs.Intersections {
+		lhit := includes(c.Left, i.Object)
+		if IntersectionAllowed(c.Operation, lhit, inl, inr) {
+			res = append(res, i)
+		}
+		if lhit {
+			inl = !inl
+		} else {
+			inr = !inr
+		}
+	}
+	return InitIntersections(res...)
+}
+
+// includes reports whether b is a or, for composites, one of a's descendants.
+func includes(a, b Shape) bool {
+	switch v := a.(type) {
+	case *Group:
+		for _, c := range v.Children {
+			if includes(c, b) {
+				return true
+			}
+		}
+		return false
+	case *CSG:
+		return includes(v.Left, b) || includes(v.Right, b)
+	}
+	return a.Equals(b)
+}
+
+func (c *CSG) Intersect(r *Ray) *Intersections {
+	r = c.prepIntersect(r)
+	xs := append(c.Left.Intersect(r).Intersections, c.Right.Intersect(r).Intersections...)
+	return c.FilterIntersections(InitIntersections(xs...))
+}
+
+func (c *CSG) Equals(s2 any) bool {
+	if v, ok := s2.(*CSG); ok {
+		return c.Id == v.Id
+	}
+	return false
+}
+
+func (c *CSG) Clone() Shape {
+	res := &CSG{c.ShapeEmbed.clone(), c.Operation, c.Left.Clone(), c.Right.Clone()}
+	res.Left.SetParent(res)
+	res.Right.SetParent(res)
+	return res
+}
+
+// NormalAt isn't meaningful for a CSG, intersections always report the child
+// that was hit.
+func (c *CSG) NormalAt(p *tuples.Tuple) *tuples.Tuple {
+	log.Fatal("Attempted to find the normal of a CSG")
+	return nil
+}
+
+func (c *CSG) Bounds() *Bounds {
+	return c.parentSpaceBounds(c.Left.Bounds().Merge(c.Right.Bounds()))
+}
@@ -0,0 +1,181 @@
+package shapes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+func TestCSGIsCreatedWithAnOperationAndTwoShapes(t *testing.T) {
+	s1 := InitSphere()
+	s2 := InitCube()
+	c, err := InitCSG(CSGUnion, s1, s2)
+	assert.Nil(t, err)
+	assert.Equal(t, CSGUnion, c.Operation)
+	assert.True(t, s1.Equals(c.Left))
+	assert.True(t, s2.Equals(c.Right))
+	assert.True(t, c.Equals(s1.Parent()))
+	assert.True(t, c.Equals(s2.Parent()))
+}
+
+func TestCSGRejectsAShapeWithAParent(t *testing.T) {
+	s1 := InitSphere()
+	s2 := InitCube()
+	_, err := InitCSG(CSGUnion, s1, s2)
+	assert.Nil(t, err)
+
+	s3 := InitSphere()
+	_, err = InitCSG(CSGUnion, s3, s2)
+	assert.NotNil(t, err)
+	assert.Nil(t, s3.Parent())
+
+	c, err := InitCSG(CSGUnion, s3, s2.Clone())
+	assert.Nil(t, err)
+	assert.True(t, c.Equals(c.Right.Parent()))
+	assert.False(t, s2.Equals(c.Right))
+}
+
+func TestEvaluatingTheRuleForACSGOperation(t *testing.T) {
+	type opt struct {
+		op     CSGOperation
+		lhit   bool
+		inl    bool
+		inr    bool
+		result bool
+	}
+	opts := []opt{
+		{CSGUnion, true, true, true, false},
+		{CSGUnion, true, true, false, true},
+		{CSGUnion, true, false, true, false},
+		{CSGUnion, true, false, false, true},
+		{CSGUnion, false, true, true, false},
+		{CSGUnion, false, true, false, false},
+		{CSGUnion, false, false, true, true},
+		{CSGUnion, false, false, false, true},
+		{CSGIntersection, true, true, true, true},
+		{CSGIntersection, true, true, false, false},
+		{CSGIntersection, true, false, true, true},
+		{CSGIntersection, true, false, false, false},
+		{CSGIntersection, false, true, true, true},
+		{CSGIntersection, false, true, false, true},
+		{CSGIntersection, false, false, true, false},
+		{CSGIntersection, false, false, false, false},
+		{CSGDifference, true, true, true, false},
+		{CSGDifference, true, true, false, true},
+		{CSGDifference, true, false, true, false},
+		{CSGDifference, true, false, false, true},
+		{CSGDifference, false, true, true, true},
+		{CSGDifference, false, true, false, true},
+		{CSGDifference, false, false, true, false},
+		{CSGDifference, false, false, false, false},
+	}
+	for _, o := range opts {
+		assert.Equal(t, o.result, IntersectionAllowed(o.op, o.lhit, o.inl, o.inr), o)
+	}
+}
+
+func TestFilteringAListOfIntersections(t *testing.T) {
+	type opt struct {
+		op CSGOperation
+		x0 int
+		x1 int
+	}
+	opts := []opt{
+		{CSGUnion, 0, 3},
+		{CSGIntersection, 1, 2},
+		{CSGDifference, 0, 1},
+	}
+	for _, o := range opts {
+		s1 := InitSphere()
+		s2 := InitCube()
+		c, err := InitCSG(o.op, s1, s2)
+		assert.Nil(t, err)
+		xs := InitIntersections(
+			InitIntersection(1, s1),
+			InitIntersection(2, s2),
+			InitIntersection(3, s1),
+			InitIntersection(4, s2),
+		)
+		res := c.FilterIntersections(xs)
+		assert.Equal(t, 2, len(res.Intersections))
+		assert.True(t, xs.Intersections[o.x0].Equals(res.Intersections[0]))
+		assert.True(t, xs.Intersections[o.x1].Equals(res.Intersections[1]))
+	}
+}
+
+func TestFilteringIntersectionsOnNestedChildren(t *testing.T) {
+	s1 := InitSphere()
+	s2 := InitSphere()
+	g := InitGroup()
+	g.AddChild(s2)
+	u, err := InitCSG(CSGUnion, InitCube(), s1)
+	assert.Nil(t, err)
+	c, err := InitCSG(CSGDifference, u, g)
+	assert.Nil(t, err)
+	xs := InitIntersections(
+		InitIntersection(1, s1),
+		InitIntersection(2, s2),
+		InitIntersection(3, s1),
+		InitIntersection(4, s2),
+	)
+	res := c.FilterIntersections(xs)
+	assert.Equal(t, 2, len(res.Intersections))
+	assert.Equal(t, 1.0, res.Intersections[0].T)
+	assert.Equal(t, 2.0, res.Intersections[1].T)
+}
+
+func TestARayMissesACSGObject(t *testing.T) {
+	c, err := InitCSG(CSGUnion, InitSphere(), InitCube())
+	assert.Nil(t, err)
+	r := InitRay(tuples.InitPoint(0, 2, -5), tuples.InitVector(0, 0, 1))
+	assert.Empty(t, c.Intersect(r).Intersections)
+}
+
+func TestARayHitsACSGObject(t *testing.T) {
+	s1 := InitSphere()
+	s2 := InitSphere()
+	s2.SetTransform(matrix.Translation(0, 0, 0.5))
+	c, err := InitCSG(CSGUnion, s1, s2)
+	assert.Nil(t, err)
+	r := InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1))
+	xs := c.Intersect(r)
+	assert.Equal(t, 2, len(xs.Intersections))
+	assert.Equal(t, 4.0, xs.Intersections[0].T)
+	assert.True(t, s1.Equals(xs.Intersections[0].Object))
+	assert.Equal(t, 6.5, xs.Intersections[1].T)
+	assert.True(t, s2.Equals(xs.Intersections[1].Object))
+}
+
+func TestCarvingAHoleInACube(t *testing.T) {
+	cube := InitCube()
+	hole := InitCylinder()
+	hole.Minimum = -2
+	hole.Maximum = 2
+	hole.Closed = true
+	hole.SetTransform(matrix.Scaling(0.5, 1, 0.5))
+	c, err := InitCSG(CSGDifference, cube, hole)
+	assert.Nil(t, err)
+	c.SetTransform(matrix.Translation(0, 0, 5))
+
+	r := InitRay(tuples.InitPoint(0, 5, 5), tuples.InitVector(0, -1, 0))
+	assert.Empty(t, c.Intersect(r).Intersections)
+
+	r = InitRay(tuples.InitPoint(0.75, 5, 5), tuples.InitVector(0, -1, 0))
+	xs := c.Intersect(r)
+	assert.Equal(t, 2, len(xs.Intersections))
+	assert.Equal(t, 4.0, xs.Intersections[0].T)
+	assert.True(t, tuples.InitVector(0, 1, 0).Equals(xs.Hit().Object.NormalAt(r.Position(xs.Hit().T))))
+}
+
+func TestCSGBounds(t *testing.T) {
+	s1 := InitSphere()
+	s2 := InitSphere()
+	s2.SetTransform(matrix.Translation(2, 0, 0))
+	c, err := InitCSG(CSGIntersection, s1, s2)
+	assert.Nil(t, err)
+	b := c.Bounds()
+	assert.True(t, tuples.InitPoint(-1, -1, -1).Equals(b.Min))
+	assert.True(t, tuples.InitPoint(3, 1, 1).Equals(b.Max))
+}
@@ -0,0 +1,88 @@
+package shapes
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+// Cube is axis aligned and spans -1 to 1 on every axis before transformation.
+type Cube struct {
+	*ShapeEmbed
+}
+
+func InitCube() *Cube {
+	return &Cube{
+		InitShapeEmbed(nil, nil),
+	}
+}
+
+func (s Cube) Intersect(r *Ray) *Intersections {
+	r = s.prepIntersect(r)
+
+	xtmin, xtmax := checkAxis(r.Origin.X, r.Direction.X, -1, 1)
+	ytmin, ytmax := checkAxis(r.Origin.Y, r.Direction.Y, -1, 1)
+	ztmin, ztmax := checkAxis(r.Origin.Z, r.Direction.Z, -1, 1)
+
+	tmin := math.Max(xtmin, math.Max(ytmin, ztmin))
+	tmax := math.Min(xtmax, math.Min(ytmax, ztmax))
+	if tmin > tmax {
+		return InitIntersections()
+	}
+	return InitIntersections(InitIntersection(tmin, s), InitIntersection(tmax, s))
+}
+
+// checkAxis finds where the ray enters and leaves the slab between min and
+// max along one axis.
+func checkAxis(origin, direction, min, max float64) (float64, float64) {
+	tminNumerator := min - origin
+	tmaxNumerator := max - origin
+
+	var tmin, tmax float64
+	if math.Abs(direction) >= maths.EPSILON {
+		tmin = tminNumerator / direction
+		tmax = tmaxNumerator / direction
+	} else {
+		tmin = tminNumerator * math.Inf(1)
+		tmax = tmaxNumerator * math.Inf(1)
+	}
+	if tmin > tmax {
+		return tmax, tmin
+	}
+	return tmin, tmax
+}
+
+func (s Cube) Equals(s2 any) bool {
+	if v, ok := s2.(Cube); ok {
+		return s.Id == v.Id
+	}
+	if v, ok := s2.(*Cube); ok {
+		return s.Id == v.Id
+	}
+	return false
+}
+
+func (s Cube) Clone() Shape {
+	s.ShapeEmbed = s.ShapeEmbed.clone()
+	return &s
+}
+
+func (s Cube) NormalAt(p *tuples.Tuple) *tuples.Tuple {
+	return s.normalAtPost(cubeNormal(s.normalAtPre(p)))
+}
+
+// cubeNormal picks the face the point lies on by its largest component.
+func cubeNormal(p *tuples.Tuple) *tuples.Tuple {
+	maxc := math.Max(math.Abs(p.X), math.Max(math.Abs(p.Y), math.Abs(p.Z)))
+	if maxc == math.Abs(p.X) {
+		return tuples.InitVector(p.X, 0, 0)
+	} else if maxc == math.Abs(p.Y) {
+		return tuples.InitVector(0, p.Y, 0)
+	}
+	return tuples.InitVector(0, 0, p.Z)
+}
+
+func (s Cube) Bounds() *Bounds {
+	return s.parentSpaceBounds(InitBounds(tuples.InitPoint(-1, -1, -1), tuples.InitPoint(1, 1, 1)))
+}
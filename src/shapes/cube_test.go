@@ -0,0 +1,104 @@
+package shapes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+func TestARayIntersectsACube(t *testing.T) {
+	type opt struct {
+		s  string
+		r  *Ray
+		t1 float64
+		t2 float64
+	}
+	opts := []opt{
+		{s: "+x", r: InitRay(tuples.InitPoint(5, 0.5, 0), tuples.InitVector(-1, 0, 0)), t1: 4, t2: 6},
+		{s: "-x", r: InitRay(tuples.InitPoint(-5, 0.5, 0), tuples.InitVector(1, 0, 0)), t1: 4, t2: 6},
+		{s: "+y", r: InitRay(tuples.InitPoint(0.5, 5, 0), tuples.InitVector(0, -1, 0)), t1: 4, t2: 6},
+		{s: "-y", r: InitRay(tuples.InitPoint(0.5, -5, 0), tuples.InitVector(0, 1, 0)), t1: 4, t2: 6},
+		{s: "+z", r: InitRay(tuples.InitPoint(0.5, 0, 5), tuples.InitVector(0, 0, -1)), t1: 4, t2: 6},
+		{s: "-z", r: InitRay(tuples.InitPoint(0.5, 0, -5), tuples.InitVector(0, 0, 1)), t1: 4, t2: 6},
+		{s: "inside", r: InitRay(tuples.InitPoint(0, 0.5, 0), tuples.InitVector(0, 0, 1)), t1: -1, t2: 1},
+	}
+	for _, o := range opts {
+		c := InitCube()
+		xs := c.Intersect(o.r)
+		assert.Equal(t, 2, len(xs.Intersections), o.s)
+		assert.Equal(t, o.t1, xs.Intersections[0].T, o.s)
+		assert.Equal(t, o.t2, xs.Intersections[1].T, o.s)
+	}
+}
+
+func TestARayMissesACube(t *testing.T) {
+	rays := []*Ray{
+		InitRay(tuples.InitPoint(-2, 0, 0), tuples.InitVector(0.2673, 0.5345, 0.8018)),
+		InitRay(tuples.InitPoint(0, -2, 0), tuples.InitVector(0.8018, 0.2673, 0.5345)),
+		InitRay(tuples.InitPoint(0, 0, -2), tuples.InitVector(0.5345, 0.8018, 0.2673)),
+		InitRay(tuples.InitPoint(2, 0, 2), tuples.InitVector(0, 0, -1)),
+		InitRay(tuples.InitPoint(0, 2, 2), tuples.InitVector(0, -1, 0)),
+		InitRay(tuples.InitPoint(2, 2, 0), tuples.InitVector(-1, 0, 0)),
+	}
+	for _, r := range rays {
+		c := InitCube()
+		xs := c.Intersect(r)
+		assert.Equal(t, 0, len(xs.Intersections))
+	}
+}
+
+func TestIntersectingATransformedCube(t *testing.T) {
+	r := InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1))
+	c := InitCube()
+	c.SetTransform(matrix.Chain(matrix.Scaling(2, 2, 2), matrix.Translation(0, 0, 1)))
+	xs := c.Intersect(r)
+	assert.Equal(t, 2, len(xs.Intersections))
+	assert.Equal(t, 4.0, xs.Intersections[0].T)
+	assert.Equal(t, 8.0, xs.Intersections[1].T)
+	assert.True(t, c.Equals(xs.Hit().Object))
+}
+
+func TestNormalOnACube(t *testing.T) {
+	type opt struct {
+		p *tuples.Tuple
+		v *tuples.Tuple
+	}
+	opts := []opt{
+		{p: tuples.InitPoint(1, 0.5, -0.8), v: tuples.InitVector(1, 0, 0)},
+		{p: tuples.InitPoint(-1, -0.2, 0.9), v: tuples.InitVector(-1, 0, 0)},
+		{p: tuples.InitPoint(-0.4, 1, -0.1), v: tuples.InitVector(0, 1, 0)},
+		{p: tuples.InitPoint(0.3, -1, -0.7), v: tuples.InitVector(0, -1, 0)},
+		{p: tuples.InitPoint(-0.6, 0.3, 1), v: tuples.InitVector(0, 0, 1)},
+		{p: tuples.InitPoint(0.4, 0.4, -1), v: tuples.InitVector(0, 0, -1)},
+		{p: tuples.InitPoint(1, 1, 1), v: tuples.InitVector(1, 0, 0)},
+		{p: tuples.InitPoint(-1, -1, -1), v: tuples.InitVector(-1, 0, 0)},
+	}
+	for _, o := range opts {
+		c := InitCube()
+		assert.True(t, o.v.Equals(c.NormalAt(o.p)), o.p)
+	}
+}
+
+func TestNormalOnATransformedCube(t *testing.T) {
+	c := InitCube()
+	c.SetTransform(matrix.Chain(matrix.Scaling(1, 2, 1), matrix.Translation(0, 1, 0)))
+	assert.True(t, tuples.InitVector(0, 1, 0).Equals(c.NormalAt(tuples.InitPoint(0.5, 3, 0.5))))
+}
+
+func TestCubeBounds(t *testing.T) {
+	c := InitCube()
+	c.SetTransform(matrix.Translation(1, 2, 3))
+	b := c.Bounds()
+	assert.True(t, tuples.InitPoint(0, 1, 2).Equals(b.Min))
+	assert.True(t, tuples.InitPoint(2, 3, 4).Equals(b.Max))
+}
+
+func TestCubeMayBeAssignedAMaterial(t *testing.T) {
+	c := InitCube()
+	m := InitMaterial(viz.Black(), 1, 1, 1, 1)
+	c.SetMaterial(m)
+	assert.True(t, c.Material().Equals(InitMaterial(viz.Black(), 1, 1, 1, 1)))
+}
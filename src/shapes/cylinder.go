@@ -0,0 +1,102 @@
+package shapes
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+// Cylinder has radius 1 around the y axis. It extends between Minimum and
+// Maximum (exclusive), which default to infinity, and has end caps when
+// Closed.
+type Cylinder struct {
+	*ShapeEmbed
+	Minimum float64
+	Maximum float64
+	Closed  bool
+}
+
+func InitCylinder() *Cylinder {
+	return &Cylinder{
+		InitShapeEmbed(nil, nil),
+		math.Inf(-1),
+		math.Inf(1),
+		false,
+	}
+}
+
+func (s Cylinder) Intersect(r *Ray) *Intersections {
+	r = s.prepIntersect(r)
+	xs := InitIntersections()
+
+	a := math.Pow(r.Direction.X, 2) + math.Pow(r.Direction.Z, 2)
+	// rays parallel to the y axis can only hit the caps
+	if !maths.FuzzyEquals(a, 0) {
+		b := 2*r.Origin.X*r.Direction.X + 2*r.Origin.Z*r.Direction.Z
+		c := math.Pow(r.Origin.X, 2) + math.Pow(r.Origin.Z, 2) - 1
+		d := math.Pow(b, 2) - 4*a*c
+		if d < 0 {
+			return xs
+		}
+		t0 := (-b - math.Sqrt(d)) / (2 * a)
+		t1 := (-b + math.Sqrt(d)) / (2 * a)
+		for _, t := range []float64{t0, t1} {
+			y := r.Origin.Y + t*r.Direction.Y
+			if s.Minimum < y && y < s.Maximum {
+				xs.Add(InitIntersection(t, s))
+			}
+		}
+	}
+
+	if s.Closed {
+		intersectCaps(r, s, s.Minimum, s.Maximum, func(y float64) float64 { return 1 }, xs)
+	}
+	return xs
+}
+
+// intersectCaps adds hits with the discs at y = min and y = max, each with
+// the radius the shape has at that height.
+func intersectCaps(r *Ray, s Shape, min, max float64, radius func(y float64) float64, xs *Intersections) {
+	if maths.FuzzyEquals(r.Direction.Y, 0) {
+		return
+	}
+	for _, y := range []float64{min, max} {
+		t := (y - r.Origin.Y) / r.Direction.Y
+		x := r.Origin.X + t*r.Direction.X
+		z := r.Origin.Z + t*r.Direction.Z
+		if math.Pow(x, 2)+math.Pow(z, 2) <= math.Pow(radius(y), 2) {
+			xs.Add(InitIntersection(t, s))
+		}
+	}
+}
+
+func (s Cylinder) Equals(s2 any) bool {
+	if v, ok := s2.(Cylinder); ok {
+		return s.Id == v.Id
+	}
+	if v, ok := s2.(*Cylinder); ok {
+		return s.Id == v.Id
+	}
+	return false
+}
+
+func (s Cylinder) Clone() Shape {
+	s.ShapeEmbed = s.ShapeEmbed.clone()
+	return &s
+}
+
+func (s Cylinder) NormalAt(p *tuples.Tuple) *tuples.Tuple {
+	lp := s.normalAtPre(p)
+	dist := math.Pow(lp.X, 2) + math.Pow(lp.Z, 2)
+	if dist < 1 && lp.Y >= s.Maximum-maths.EPSILON {
+		return s.normalAtPost(tuples.InitVector(0, 1, 0))
+	} else if dist < 1 && lp.Y <= s.Minimum+maths.EPSILON {
+		return s.normalAtPost(tuples.InitVector(0, -1, 0))
+	}
+	return s.normalAtPost(tuples.InitVector(lp.X, 0, lp.Z))
+}
+
+func (s Cylinder) Bounds() *Bounds {
+	return s.parentSpaceBounds(InitBounds(tuples.InitPoint(-1, s.Minimum, -1), tuples.InitPoint(1, s.Maximum, 1)))
+}
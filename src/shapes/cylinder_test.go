@@ -0,0 +1,143 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+func TestARayMissesACylinder(t *testing.T) {
+	rays := []*Ray{
+		InitRay(tuples.InitPoint(1, 0, 0), tuples.InitVector(0, 1, 0)),
+		InitRay(tuples.InitPoint(0, 0, 0), tuples.InitVector(0, 1, 0)),
+		InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(1, 1, 1).Normalize()),
+	}
+	for _, r := range rays {
+		c := InitCylinder()
+		assert.Equal(t, 0, len(c.Intersect(r).Intersections))
+	}
+}
+
+func TestARayStrikesACylinder(t *testing.T) {
+	type opt struct {
+		r  *Ray
+		t0 float64
+		t1 float64
+	}
+	opts := []opt{
+		{r: InitRay(tuples.InitPoint(1, 0, -5), tuples.InitVector(0, 0, 1)), t0: 5, t1: 5},
+		{r: InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1)), t0: 4, t1: 6},
+		{r: InitRay(tuples.InitPoint(0.5, 0, -5), tuples.InitVector(0.1, 1, 1).Normalize()), t0: 6.80798, t1: 7.08872},
+	}
+	for _, o := range opts {
+		c := InitCylinder()
+		xs := c.Intersect(o.r)
+		assert.Equal(t, 2, len(xs.Intersections))
+		assert.True(t, maths.FuzzyEquals(o.t0, xs.Intersections[0].T), xs.Intersections[0].T)
+		assert.True(t, maths.FuzzyEquals(o.t1, xs.Intersections[1].T), xs.Intersections[1].T)
+	}
+}
+
+func TestNormalOnACylinder(t *testing.T) {
+	type opt struct {
+		p *tuples.Tuple
+		v *tuples.Tuple
+	}
+	opts := []opt{
+		{p: tuples.InitPoint(1, 0, 0), v: tuples.InitVector(1, 0, 0)},
+		{p: tuples.InitPoint(0, 5, -1), v: tuples.InitVector(0, 0, -1)},
+		{p: tuples.InitPoint(0, -2, 1), v: tuples.InitVector(0, 0, 1)},
+		{p: tuples.InitPoint(-1, 1, 0), v: tuples.InitVector(-1, 0, 0)},
+	}
+	for _, o := range opts {
+		c := InitCylinder()
+		assert.True(t, o.v.Equals(c.NormalAt(o.p)), o.p)
+	}
+}
+
+func TestDefaultCylinderIsInfiniteAndOpen(t *testing.T) {
+	c := InitCylinder()
+	assert.Equal(t, math.Inf(-1), c.Minimum)
+	assert.Equal(t, math.Inf(1), c.Maximum)
+	assert.False(t, c.Closed)
+	assert.False(t, c.Bounds().IsFinite())
+}
+
+func TestIntersectingAConstrainedCylinder(t *testing.T) {
+	type opt struct {
+		s     string
+		r     *Ray
+		count int
+	}
+	opts := []opt{
+		{s: "diagonal from inside", r: InitRay(tuples.InitPoint(0, 1.5, 0), tuples.InitVector(0.1, 1, 0).Normalize()), count: 0},
+		{s: "above", r: InitRay(tuples.InitPoint(0, 3, -5), tuples.InitVector(0, 0, 1)), count: 0},
+		{s: "below", r: InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1)), count: 0},
+		{s: "at maximum", r: InitRay(tuples.InitPoint(0, 2, -5), tuples.InitVector(0, 0, 1)), count: 0},
+		{s: "at minimum", r: InitRay(tuples.InitPoint(0, 1, -5), tuples.InitVector(0, 0, 1)), count: 0},
+		{s: "through the middle", r: InitRay(tuples.InitPoint(0, 1.5, -2), tuples.InitVector(0, 0, 1)), count: 2},
+	}
+	for _, o := range opts {
+		c := InitCylinder()
+		c.Minimum = 1
+		c.Maximum = 2
+		assert.Equal(t, o.count, len(c.Intersect(o.r).Intersections), o.s)
+	}
+}
+
+func TestIntersectingTheCapsOfAClosedCylinder(t *testing.T) {
+	type opt struct {
+		r     *Ray
+		count int
+	}
+	opts := []opt{
+		{r: InitRay(tuples.InitPoint(0, 3, 0), tuples.InitVector(0, -1, 0)), count: 2},
+		{r: InitRay(tuples.InitPoint(0, 3, -2), tuples.InitVector(0, -1, 2).Normalize()), count: 2},
+		{r: InitRay(tuples.InitPoint(0, 4, -2), tuples.InitVector(0, -1, 1).Normalize()), count: 2},
+		{r: InitRay(tuples.InitPoint(0, 0, -2), tuples.InitVector(0, 1, 2).Normalize()), count: 2},
+		{r: InitRay(tuples.InitPoint(0, -1, -2), tuples.InitVector(0, 1, 1).Normalize()), count: 2},
+	}
+	for _, o := range opts {
+		c := InitCylinder()
+		c.Minimum = 1
+		c.Maximum = 2
+		c.Closed = true
+		assert.Equal(t, o.count, len(c.Intersect(o.r).Intersections), o.r.Origin)
+	}
+}
+
+func TestNormalOnTheCapsOfACylinder(t *testing.T) {
+	type opt struct {
+		p *tuples.Tuple
+		v *tuples.Tuple
+	}
+	opts := []opt{
+		{p: tuples.InitPoint(0, 1, 0), v: tuples.InitVector(0, -1, 0)},
+		{p: tuples.InitPoint(0.5, 1, 0), v: tuples.InitVector(0, -1, 0)},
+		{p: tuples.InitPoint(0, 1, 0.5), v: tuples.InitVector(0, -1, 0)},
+		{p: tuples.InitPoint(0, 2, 0), v: tuples.InitVector(0, 1, 0)},
+		{p: tuples.InitPoint(0.5, 2, 0), v: tuples.InitVector(0, 1, 0)},
+		{p: tuples.InitPoint(0, 2, 0.5), v: tuples.InitVector(0, 1, 0)},
+	}
+	for _, o := range opts {
+		c := InitCylinder()
+		c.Minimum = 1
+		c.Maximum = 2
+		c.Closed = true
+		assert.True(t, o.v.Equals(c.NormalAt(o.p)), o.p)
+	}
+}
+
+func TestTruncatedCylinderBounds(t *testing.T) {
+	c := InitCylinder()
+	c.Minimum = -5
+	c.Maximum = 3
+	c.SetTransform(matrix.Translation(1, 0, 0))
+	b := c.Bounds()
+	assert.True(t, tuples.InitPoint(0, -5, -1).Equals(b.Min))
+	assert.True(t, tuples.InitPoint(2, 3, 1).Equals(b.Max))
+}
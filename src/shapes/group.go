@@ -0,0 +1,82 @@
+package shapes
+
+import (
+	"log"
+
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+// Group is a composite of child shapes. Its transform applies on top of each
+// child's own, so a model can be built once in a group and placed anywhere
+// with the group's transform. A shape belongs to at most one group, to place
+// a model more than once add a Clone of it to each further group.
+type Group struct {
+	*ShapeEmbed
+	Children []Shape
+	bvh      BVHCache
+}
+
+func InitGroup() *Group {
+	return &Group{
+		ShapeEmbed: InitShapeEmbed(nil, nil),
+	}
+}
+
+// AddChild adds the shapes to the group, or none of them if any already has
+// a parent.
+func (g *Group) AddChild(ss ...Shape) error {
+	if err := checkOrphans(ss...); err != nil {
+		return err
+	}
+	for _, s := range ss {
+		s.SetParent(g)
+		g.Children = append(g.Children, s)
+	}
+	g.Invalidate()
+	return nil
+}
+
+func (g *Group) Clone() Shape {
+	c := &Group{ShapeEmbed: g.ShapeEmbed.clone()}
+	for _, s := range g.Children {
+		s = s.Clone()
+		s.SetParent(c)
+		c.Children = append(c.Children, s)
+	}
+	return c
+}
+
+// Invalidate drops the group's BVH as well as telling its own parents.
+func (g *Group) Invalidate() {
+	g.bvh.Invalidate()
+	g.ShapeEmbed.Invalidate()
+}
+
+func (g *Group) Intersect(r *Ray) *Intersections {
+	return g.bvh.Get(g.Children).Intersect(g.prepIntersect(r))
+}
+
+func (g *Group) Equals(s2 any) bool {
+	if v, ok := s2.(*Group); ok {
+		return g.Id == v.Id
+	}
+	return false
+}
+
+// NormalAt isn't meaningful for a group, intersections always report the
+// child that was hit.
+func (g *Group) NormalAt(p *tuples.Tuple) *tuples.Tuple {
+	log.Fatal("Attempted to find the normal of a group")
+	return nil
+}
+
+func (g *Group) Bounds() *Bounds {
+	b := EmptyBounds()
+	for _, c := range g.Children {
+		b = b.Merge(c.Bounds())
+	}
+	if len(g.Children) == 0 {
+		return b
+	}
+	return g.parentSpaceBounds(b)
+}
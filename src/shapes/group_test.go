@@ -0,0 +1,213 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+func TestCreatingANewGroup(t *testing.T) {
+	g := InitGroup()
+	assert.True(t, matrix.InitMatrixIdentity(4).Equals(g.Transform()))
+	assert.Empty(t, g.Children)
+}
+
+func TestAShapeHasAParentAttribute(t *testing.T) {
+	s := InitTestShape()
+	assert.Nil(t, s.Parent())
+}
+
+func TestAddingAChildToAGroup(t *testing.T) {
+	g := InitGroup()
+	s := InitSphere()
+	g.AddChild(s)
+	assert.Equal(t, 1, len(g.Children))
+	assert.True(t, s.Equals(g.Children[0]))
+	assert.True(t, g.Equals(s.Parent()))
+}
+
+func TestAddingAChildThatHasAParent(t *testing.T) {
+	g1 := InitGroup()
+	s := InitSphere()
+	assert.Nil(t, g1.AddChild(s))
+
+	g2 := InitGroup()
+	other := InitSphere()
+	assert.NotNil(t, g2.AddChild(other, s))
+	assert.Empty(t, g2.Children)
+	assert.Nil(t, other.Parent())
+	assert.True(t, g1.Equals(s.Parent()))
+}
+
+func TestIntersectingARayWithAnEmptyGroup(t *testing.T) {
+	g := InitGroup()
+	r := InitRay(tuples.InitPoint(0, 0, 0), tuples.InitVector(0, 0, 1))
+	assert.Empty(t, g.Intersect(r).Intersections)
+}
+
+func TestIntersectingARayWithANonemptyGroup(t *testing.T) {
+	g := InitGroup()
+	s1 := InitSphere()
+	s2 := InitSphere()
+	s2.SetTransform(matrix.Translation(0, 0, -3))
+	s3 := InitSphere()
+	s3.SetTransform(matrix.Translation(5, 0, 0))
+	g.AddChild(s1, s2, s3)
+	r := InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1))
+	xs := g.Intersect(r)
+	assert.Equal(t, 4, len(xs.Intersections))
+	assert.True(t, s2.Equals(xs.Intersections[0].Object))
+	assert.True(t, s2.Equals(xs.Intersections[1].Object))
+	assert.True(t, s1.Equals(xs.Intersections[2].Object))
+	assert.True(t, s1.Equals(xs.Intersections[3].Object))
+}
+
+func TestIntersectingATransformedGroup(t *testing.T) {
+	g := InitGroup()
+	g.SetTransform(matrix.Scaling(2, 2, 2))
+	s := InitSphere()
+	s.SetTransform(matrix.Translation(5, 0, 0))
+	g.AddChild(s)
+	r := InitRay(tuples.InitPoint(10, 0, -10), tuples.InitVector(0, 0, 1))
+	assert.Equal(t, 2, len(g.Intersect(r).Intersections))
+}
+
+func TestConvertingAPointFromWorldToObjectSpace(t *testing.T) {
+	g1 := InitGroup()
+	g1.SetTransform(matrix.RotationY(1.0 / 2.0))
+	g2 := InitGroup()
+	g2.SetTransform(matrix.Scaling(2, 2, 2))
+	g1.AddChild(g2)
+	s := InitSphere()
+	s.SetTransform(matrix.Translation(5, 0, 0))
+	g2.AddChild(s)
+	p := s.WorldToObject(tuples.InitPoint(-2, 0, -10))
+	assert.True(t, tuples.InitPoint(0, 0, -1).Equals(p))
+}
+
+func TestConvertingANormalFromObjectToWorldSpace(t *testing.T) {
+	g1 := InitGroup()
+	g1.SetTransform(matrix.RotationY(1.0 / 2.0))
+	g2 := InitGroup()
+	g2.SetTransform(matrix.Scaling(1, 2, 3))
+	g1.AddChild(g2)
+	s := InitSphere()
+	s.SetTransform(matrix.Translation(5, 0, 0))
+	g2.AddChild(s)
+	n := s.NormalToWorld(tuples.InitVector(math.Sqrt(3)/3, math.Sqrt(3)/3, math.Sqrt(3)/3))
+	assert.True(t, tuples.InitVector(0.28571, 0.42857, -0.85714).Equals(n))
+}
+
+func TestFindingTheNormalOnAChildObject(t *testing.T) {
+	g1 := InitGroup()
+	g1.SetTransform(matrix.RotationY(1.0 / 2.0))
+	g2 := InitGroup()
+	g2.SetTransform(matrix.Scaling(1, 2, 3))
+	g1.AddChild(g2)
+	s := InitSphere()
+	s.SetTransform(matrix.Translation(5, 0, 0))
+	g2.AddChild(s)
+	n := s.NormalAt(tuples.InitPoint(1.7321, 1.1547, -5.5774))
+	assert.True(t, tuples.InitVector(0.2857, 0.42854, -0.85716).Equals(n), n)
+}
+
+func TestGroupBoundsContainTheChildren(t *testing.T) {
+	g := InitGroup()
+	g.SetTransform(matrix.Translation(0, 1, 0))
+	s := InitSphere()
+	s.SetTransform(matrix.Translation(2, 0, 0))
+	c := InitCube()
+	c.SetTransform(matrix.Translation(-2, 0, 0))
+	g.AddChild(s, c)
+	b := g.Bounds()
+	assert.True(t, tuples.InitPoint(-3, 0, -1).Equals(b.Min))
+	assert.True(t, tuples.InitPoint(3, 2, 1).Equals(b.Max))
+}
+
+func TestGroupIntersectionsFollowChildChanges(t *testing.T) {
+	g := InitGroup()
+	s := InitSphere()
+	c := InitCylinder()
+	c.Minimum = 0
+	c.Maximum = 1
+	c.SetTransform(matrix.Translation(0, 0, 3))
+	g.AddChild(s, c)
+	r := InitRay(tuples.InitPoint(5, 3, -5), tuples.InitVector(0, 0, 1))
+	assert.Empty(t, g.Intersect(r).Intersections)
+
+	s.SetTransform(matrix.Translation(5, 3, 0))
+	assert.Equal(t, 2, len(g.Intersect(r).Intersections))
+
+	r = InitRay(tuples.InitPoint(0, 3, -5), tuples.InitVector(0, 0, 1))
+	c.Maximum = 5
+	c.Invalidate()
+	assert.Equal(t, 2, len(g.Intersect(r).Intersections))
+}
+
+func TestNestedGroupIntersectionsFollowChildTransforms(t *testing.T) {
+	outer := InitGroup()
+	inner := InitGroup()
+	s := InitSphere()
+	inner.AddChild(s)
+	outer.AddChild(inner)
+	r := InitRay(tuples.InitPoint(5, 0, -5), tuples.InitVector(0, 0, 1))
+	assert.Empty(t, outer.Intersect(r).Intersections)
+
+	s.SetTransform(matrix.Translation(5, 0, 0))
+	assert.Equal(t, 2, len(outer.Intersect(r).Intersections))
+}
+
+func TestAddingAChildUpdatesTheBoundsOfParentGroups(t *testing.T) {
+	outer := InitGroup()
+	g := InitGroup()
+	g.AddChild(InitSphere())
+	outer.AddChild(g)
+	r := InitRay(tuples.InitPoint(10, 0, -5), tuples.InitVector(0, 0, 1))
+	assert.Empty(t, outer.Intersect(r).Intersections)
+
+	s := InitSphere()
+	s.SetTransform(matrix.Translation(10, 0, 0))
+	g.AddChild(s)
+	xs := outer.Intersect(r)
+	assert.Equal(t, 2, len(xs.Intersections))
+	assert.True(t, s.Equals(xs.Intersections[0].Object))
+}
+
+func TestCloningAGroupUnderTwoParents(t *testing.T) {
+	model := InitGroup()
+	model.SetTransform(matrix.Scaling(2, 2, 2))
+	s := InitSphere()
+	s.SetTransform(matrix.Translation(0, 1, 0))
+	model.AddChild(s)
+
+	left := InitGroup()
+	left.SetTransform(matrix.Translation(-10, 0, 0))
+	left.AddChild(model)
+	right := InitGroup()
+	right.SetTransform(matrix.Translation(10, 0, 0))
+	copied := model.Clone()
+	right.AddChild(copied)
+
+	for _, o := range []struct {
+		g *Group
+		x float64
+	}{
+		{left, -10},
+		{right, 10},
+	} {
+		r := InitRay(tuples.InitPoint(o.x, 2, -10), tuples.InitVector(0, 0, 1))
+		xs := o.g.Intersect(r)
+		assert.Equal(t, 2, len(xs.Intersections))
+		assert.True(t, maths.FuzzyEquals(8, xs.Intersections[0].T))
+		hit := xs.Intersections[0].Object
+		n := hit.NormalAt(r.Position(xs.Intersections[0].T))
+		assert.True(t, tuples.InitVector(0, 0, -1).Equals(n), n)
+	}
+	assert.False(t, s.Equals(copied.(*Group).Children[0]))
+	assert.Same(t, s.Material(), copied.(*Group).Children[0].Material())
+	assert.True(t, model.Equals(s.Parent()))
+}
@@ -1,6 +1,7 @@
 package shapes
 
 import (
+	"math"
 	"sort"
 
 	"happymonday.dev/ray-tracer/src/maths"
@@ -10,6 +11,9 @@ import (
 type Intersection struct {
 	T      float64
 	Object Shape
+	// U and V locate the hit on a triangle relative to its corners
+	U float64
+	V float64
 }
 
 type IntersectionComputations struct {
@@ -18,32 +22,102 @@ type IntersectionComputations struct {
 	Point     *tuples.Tuple
 	EyeV      *tuples.Tuple
 	NormalV   *tuples.Tuple
+	ReflectV  *tuples.Tuple
 	OverPoint *tuples.Tuple
-	Inside    bool
+	// UnderPoint sits just below the surface for starting refracted rays
+	UnderPoint *tuples.Tuple
+	Inside     bool
+	// N1 and N2 are the refractive indices on the side the ray comes from
+	// and the side it passes into
+	N1 float64
+	N2 float64
 }
 
 func InitIntersection(t float64, o Shape) *Intersection {
-	return &Intersection{t, o}
+	return &Intersection{T: t, Object: o}
+}
+
+func InitIntersectionWithUV(t float64, o Shape, u, v float64) *Intersection {
+	return &Intersection{T: t, Object: o, U: u, V: v}
 }
 
 func (i *Intersection) Equals(i2 *Intersection) bool {
 	return i.T == i2.T && i.Object.Equals(i2.Object)
 }
 
-func (i *Intersection) PrepareComputations(r *Ray) *IntersectionComputations {
+// PrepareComputations works out everything needed to shade the intersection
+// of r. xs is every intersection along r, including this one, and is walked
+// to find which objects the hit is inside for refraction.
+func (i *Intersection) PrepareComputations(r *Ray, xs *Intersections) *IntersectionComputations {
 	c := IntersectionComputations{T: i.T, Object: i.Object}
 	c.Point = r.Position(c.T)
-	c.NormalV = c.Object.NormalAt(c.Point)
+	if n, ok := c.Object.(HitNormaler); ok {
+		c.NormalV = n.NormalAtHit(c.Point, i)
+	} else {
+		c.NormalV = c.Object.NormalAt(c.Point)
+	}
 	c.EyeV = r.Direction.Negate()
 	c.Inside = c.NormalV.DotProduct(c.EyeV) < 0
 	if c.Inside {
 		c.NormalV = c.NormalV.Negate()
 	}
 
+	c.ReflectV = r.Direction.Reflect(c.NormalV)
 	c.OverPoint = c.Point.Add(c.NormalV.MultiplyScalar(maths.EPSILON))
+	c.UnderPoint = c.Point.Subtract(c.NormalV.MultiplyScalar(maths.EPSILON))
+	c.N1, c.N2 = i.refractiveIndices(xs)
 	return &c
 }
 
+// refractiveIndices tracks which objects the ray is inside as it passes
+// through each intersection until it reaches i.
+func (i *Intersection) refractiveIndices(xs *Intersections) (float64, float64) {
+	n1, n2 := RefractiveIndexVacuum, RefractiveIndexVacuum
+	containers := []Shape{}
+	for _, x := range xs.Intersections {
+		if x == i && len(containers) > 0 {
+			n1 = containers[len(containers)-1].Material().RefractiveIndex
+		}
+
+		found := false
+		for idx, c := range containers {
+			if c.Equals(x.Object) {
+				containers = append(containers[:idx], containers[idx+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			containers = append(containers, x.Object)
+		}
+
+		if x == i {
+			if len(containers) > 0 {
+				n2 = containers[len(containers)-1].Material().RefractiveIndex
+			}
+			break
+		}
+	}
+	return n1, n2
+}
+
+// Schlick approximates the Fresnel reflectance, the fraction of light
+// reflected rather than refracted at the hit.
+func (c *IntersectionComputations) Schlick() float64 {
+	cos := c.EyeV.DotProduct(c.NormalV)
+	if c.N1 > c.N2 {
+		n := c.N1 / c.N2
+		sin2t := math.Pow(n, 2) * (1 - math.Pow(cos, 2))
+		if sin2t > 1 {
+			// total internal reflection
+			return 1
+		}
+		cos = math.Sqrt(1 - sin2t)
+	}
+	r0 := math.Pow((c.N1-c.N2)/(c.N1+c.N2), 2)
+	return r0 + (1-r0)*math.Pow(1-cos, 5)
+}
+
 type Intersections struct {
 	Intersections []*Intersection
 	hit           int
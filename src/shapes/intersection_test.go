@@ -1,6 +1,7 @@
 package shapes
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -68,7 +69,7 @@ func TestPrecomputingTheStateOfAnIntersection(t *testing.T) {
 	r := InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1))
 	s := InitSphere()
 	i := InitIntersection(4, s)
-	comps := i.PrepareComputations(r)
+	comps := i.PrepareComputations(r, InitIntersections(i))
 	assert.Equal(t, i.T, comps.T)
 	assert.True(t, s.Equals(comps.Object))
 	assert.True(t, tuples.InitPoint(0, 0, -1).Equals(comps.Point))
@@ -80,7 +81,7 @@ func TestTheHitWhenAnIntersectionOccursOutside(t *testing.T) {
 	r := InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1))
 	s := InitSphere()
 	i := InitIntersection(4, s)
-	comps := i.PrepareComputations(r)
+	comps := i.PrepareComputations(r, InitIntersections(i))
 	assert.False(t, comps.Inside)
 }
 
@@ -88,7 +89,7 @@ func TestTheHitWhenAnIntersectionOccursInside(t *testing.T) {
 	r := InitRay(tuples.InitPoint(0, 0, 0), tuples.InitVector(0, 0, 1))
 	s := InitSphere()
 	i := InitIntersection(1, s)
-	comps := i.PrepareComputations(r)
+	comps := i.PrepareComputations(r, InitIntersections(i))
 	assert.Equal(t, i.T, comps.T)
 	assert.True(t, s.Equals(comps.Object))
 	assert.True(t, tuples.InitPoint(0, 0, 1).Equals(comps.Point))
@@ -103,8 +104,104 @@ func TestTheHitShouldOffsetThePoint(t *testing.T) {
 	s.SetTransform(matrix.Translation(0, 0, 1))
 
 	i := InitIntersection(5, s)
-	comps := i.PrepareComputations(r)
+	comps := i.PrepareComputations(r, InitIntersections(i))
 
 	assert.Less(t, comps.OverPoint.Z, -maths.EPSILON/2.0)
 	assert.Less(t, comps.OverPoint.Z, comps.Point.Z)
 }
+
+func TestPrecomputingTheReflectionVector(t *testing.T) {
+	p := InitPlane()
+	r := InitRay(tuples.InitPoint(0, 1, -1), tuples.InitVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	i := InitIntersection(math.Sqrt(2), p)
+	comps := i.PrepareComputations(r, InitIntersections(i))
+	assert.True(t, tuples.InitVector(0, math.Sqrt(2)/2, math.Sqrt(2)/2).Equals(comps.ReflectV))
+}
+
+func glassSphere() *Sphere {
+	s := InitSphere()
+	s.Material().Transparency = 1
+	s.Material().RefractiveIndex = RefractiveIndexGlass
+	return s
+}
+
+func TestFindingN1AndN2AtVariousIntersections(t *testing.T) {
+	a := glassSphere()
+	a.SetTransform(matrix.Scaling(2, 2, 2))
+	a.Material().RefractiveIndex = 1.5
+	b := glassSphere()
+	b.SetTransform(matrix.Translation(0, 0, -0.25))
+	b.Material().RefractiveIndex = 2.0
+	c := glassSphere()
+	c.SetTransform(matrix.Translation(0, 0, 0.25))
+	c.Material().RefractiveIndex = 2.5
+	r := InitRay(tuples.InitPoint(0, 0, -4), tuples.InitVector(0, 0, 1))
+	xs := InitIntersections(
+		InitIntersection(2, a),
+		InitIntersection(2.75, b),
+		InitIntersection(3.25, c),
+		InitIntersection(4.75, b),
+		InitIntersection(5.25, c),
+		InitIntersection(6, a),
+	)
+	exp := [][2]float64{{1.0, 1.5}, {1.5, 2.0}, {2.0, 2.5}, {2.5, 2.5}, {2.5, 1.5}, {1.5, 1.0}}
+	for idx, e := range exp {
+		comps := xs.Intersections[idx].PrepareComputations(r, xs)
+		assert.Equal(t, e[0], comps.N1, idx)
+		assert.Equal(t, e[1], comps.N2, idx)
+	}
+}
+
+func TestTheUnderPointIsOffsetBelowTheSurface(t *testing.T) {
+	r := InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1))
+	s := glassSphere()
+	s.SetTransform(matrix.Translation(0, 0, 1))
+	i := InitIntersection(5, s)
+	comps := i.PrepareComputations(r, InitIntersections(i))
+	assert.Greater(t, comps.UnderPoint.Z, maths.EPSILON/2)
+	assert.Less(t, comps.Point.Z, comps.UnderPoint.Z)
+}
+
+func TestTheSchlickApproximation(t *testing.T) {
+	type opt struct {
+		s   string
+		r   *Ray
+		ts  []float64
+		hit int
+		exp float64
+	}
+	opts := []opt{
+		{
+			s:   "under total internal reflection",
+			r:   InitRay(tuples.InitPoint(0, 0, math.Sqrt(2)/2), tuples.InitVector(0, 1, 0)),
+			ts:  []float64{-math.Sqrt(2) / 2, math.Sqrt(2) / 2},
+			hit: 1,
+			exp: 1.0,
+		},
+		{
+			s:   "with a perpendicular viewing angle",
+			r:   InitRay(tuples.InitPoint(0, 0, 0), tuples.InitVector(0, 1, 0)),
+			ts:  []float64{-1, 1},
+			hit: 1,
+			exp: 0.04,
+		},
+		{
+			s:   "with small angle and n2 > n1",
+			r:   InitRay(tuples.InitPoint(0, 0.99, -2), tuples.InitVector(0, 0, 1)),
+			ts:  []float64{1.8589},
+			hit: 0,
+			exp: 0.48873,
+		},
+	}
+	for _, o := range opts {
+		s := glassSphere()
+		s.Material().RefractiveIndex = 1.5
+		is := []*Intersection{}
+		for _, t := range o.ts {
+			is = append(is, InitIntersection(t, s))
+		}
+		xs := InitIntersections(is...)
+		comps := xs.Intersections[o.hit].PrepareComputations(o.r, xs)
+		assert.True(t, maths.FuzzyEquals(o.exp, comps.Schlick()), o.s, comps.Schlick())
+	}
+}
@@ -3,26 +3,60 @@ package shapes
 import (
 	"log"
 
+	"happymonday.dev/ray-tracer/src/patterns"
+	"happymonday.dev/ray-tracer/src/tuples"
 	"happymonday.dev/ray-tracer/src/viz"
 )
 
+// Refractive indices of some common materials.
+const (
+	RefractiveIndexVacuum  = 1.0
+	RefractiveIndexAir     = 1.00029
+	RefractiveIndexWater   = 1.333
+	RefractiveIndexGlass   = 1.52
+	RefractiveIndexDiamond = 2.417
+)
+
 type Material struct {
-	Color     *viz.Color
+	Color *viz.Color
+	// Pattern, when set, colors the surface instead of Color
+	Pattern   patterns.Pattern
 	Ambient   float64
 	Diffuse   float64
 	Specular  float64
 	Shininess float64
+	// Reflective is how much of the color comes from reflections, 0 for
+	// none up to 1 for a perfect mirror
+	Reflective float64
+	// Transparency is how much of the color comes from light refracted
+	// through the surface, bent according to RefractiveIndex
+	Transparency    float64
+	RefractiveIndex float64
 }
 
 func DefaultMaterial() *Material {
-	return &Material{viz.InitColor(1, 1, 1), 0.1, 0.9, 0.9, 200.0}
+	return &Material{
+		Color:           viz.InitColor(1, 1, 1),
+		Ambient:         0.1,
+		Diffuse:         0.9,
+		Specular:        0.9,
+		Shininess:       200.0,
+		RefractiveIndex: RefractiveIndexVacuum,
+	}
 }
 
 func InitMaterial(c *viz.Color, a, d, sp, sh float64) *Material {
 	if a < 0 || d < 0 || sp < 0 || sh < 0 {
 		log.Fatal("Material creation attempted with negative values", a, d, sp, sh)
 	}
-	return &Material{c, a, d, sp, sh}
+	return &Material{
+		Color:           c,
+		Ambient:         a,
+		Diffuse:         d,
+		Specular:        sp,
+		Shininess:       sh,
+		RefractiveIndex: RefractiveIndexVacuum,
+	}
 }
 
 func (m *Material) Equals(m2 *Material) bool {
@@ -30,5 +64,23 @@ func (m *Material) Equals(m2 *Material) bool {
 		m.Ambient == m2.Ambient &&
 		m.Diffuse == m2.Diffuse &&
 		m.Specular == m2.Specular &&
-		m.Shininess == m2.Shininess)
+		m.Shininess == m2.Shininess &&
+		m.Reflective == m2.Reflective &&
+		m.Transparency == m2.Transparency &&
+		m.RefractiveIndex == m2.RefractiveIndex &&
+		m.Pattern == m2.Pattern)
+}
+
+// ColorAt is the material's color at a world space point on object.
+func (m *Material) ColorAt(object Shape, worldPoint *tuples.Tuple) *viz.Color {
+	if m.Pattern == nil {
+		return m.Color
+	}
+	return PatternAtShape(m.Pattern, object, worldPoint)
+}
+
+// PatternAtShape colors a world space point with a pattern applied to object,
+// converting the point through the object's and then the pattern's space.
+func PatternAtShape(p patterns.Pattern, object Shape, worldPoint *tuples.Tuple) *viz.Color {
+	return patterns.At(p, object.WorldToObject(worldPoint))
 }
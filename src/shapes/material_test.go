@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/patterns"
+	"happymonday.dev/ray-tracer/src/tuples"
 	"happymonday.dev/ray-tracer/src/viz"
 )
 
@@ -14,4 +17,59 @@ func TestDefaultMaterial(t *testing.T) {
 	assert.Equal(t, 0.9, m.Diffuse)
 	assert.Equal(t, 0.9, m.Specular)
 	assert.Equal(t, 200.0, m.Shininess)
+	assert.Equal(t, 0.0, m.Reflective)
+	assert.Equal(t, 0.0, m.Transparency)
+	assert.Equal(t, 1.0, m.RefractiveIndex)
+}
+
+func TestPatternsWithTransformedObjectsAndPatterns(t *testing.T) {
+	type opt struct {
+		s  string
+		st *matrix.Matrix
+		pt *matrix.Matrix
+		p  *tuples.Tuple
+	}
+	opts := []opt{
+		{
+			s:  "Stripes with an object transformation",
+			st: matrix.Scaling(2, 2, 2),
+			pt: matrix.InitMatrixIdentity(4),
+			p:  tuples.InitPoint(1.5, 0, 0),
+		},
+		{
+			s:  "Stripes with a pattern transformation",
+			st: matrix.InitMatrixIdentity(4),
+			pt: matrix.Scaling(2, 2, 2),
+			p:  tuples.InitPoint(1.5, 0, 0),
+		},
+		{
+			s:  "Stripes with both an object and a pattern transformation",
+			st: matrix.Scaling(2, 2, 2),
+			pt: matrix.Translation(0.5, 0, 0),
+			p:  tuples.InitPoint(2.5, 0, 0),
+		},
+	}
+	for _, o := range opts {
+		s := InitSphere()
+		s.SetTransform(o.st)
+		p := patterns.InitStripePattern(viz.InitColor(1, 1, 1), viz.Black())
+		p.SetTransform(o.pt)
+		assert.True(t, viz.InitColor(1, 1, 1).Equals(PatternAtShape(p, s, o.p)), o.s)
+	}
+}
+
+func TestPatternsOnChildObjects(t *testing.T) {
+	g := InitGroup()
+	g.SetTransform(matrix.Scaling(2, 2, 2))
+	s := InitSphere()
+	s.SetTransform(matrix.Translation(5, 0, 0))
+	g.AddChild(s)
+	s.Material().Pattern = patterns.InitGradientPattern(viz.Black(), viz.InitColor(1, 1, 1))
+	c := s.Material().ColorAt(s, tuples.InitPoint(10.5, 0, 0))
+	assert.True(t, viz.InitColor(0.25, 0.25, 0.25).Equals(c))
+}
+
+func TestMaterialColorWithoutAPattern(t *testing.T) {
+	s := InitSphere()
+	assert.True(t, s.Material().Color.Equals(s.Material().ColorAt(s, tuples.InitPoint(0, 0, 1))))
 }
@@ -2,7 +2,6 @@ package shapes
 
 import (
 	"math"
-	"sync"
 
 	"happymonday.dev/ray-tracer/src/maths"
 	"happymonday.dev/ray-tracer/src/tuples"
@@ -10,29 +9,19 @@ import (
 
 type Plane struct {
 	*ShapeEmbed
-	xs sync.Map
 }
 
 func InitPlane() *Plane {
 	return &Plane{
 		InitShapeEmbed(nil, nil),
-		sync.Map{},
 	}
 }
 
 func (s Plane) Intersect(r *Ray) *Intersections {
-	if v, ok := s.xs.Load(r.Id); ok {
-		if xs, ok := v.(*Intersections); ok {
-			return xs
-		}
-	}
-	xs := InitIntersections()
-	s.xs.Store(r.Id, &xs)
-
 	r = s.prepIntersect(r)
 
 	if math.Abs(r.Direction.Y) < maths.EPSILON {
-		return xs
+		return InitIntersections()
 	}
 
 	t := -r.Origin.Y / r.Direction.Y
@@ -50,6 +39,16 @@ func (s Plane) Equals(s2 any) bool {
 	return false
 }
 
+func (s Plane) Clone() Shape {
+	s.ShapeEmbed = s.ShapeEmbed.clone()
+	return &s
+}
+
 func (s Plane) NormalAt(p *tuples.Tuple) *tuples.Tuple {
 	return s.normalAtPost(tuples.InitPoint(0, 1, 0))
 }
+
+func (s Plane) Bounds() *Bounds {
+	inf := math.Inf(1)
+	return s.parentSpaceBounds(InitBounds(tuples.InitPoint(-inf, 0, -inf), tuples.InitPoint(inf, 0, inf)))
+}
@@ -3,19 +3,17 @@ package shapes
 import (
 	"log"
 
-	"github.com/segmentio/ksuid"
 	"happymonday.dev/ray-tracer/src/matrix"
 	"happymonday.dev/ray-tracer/src/tuples"
 )
 
 type Ray struct {
-	Id        ksuid.KSUID
 	Origin    *tuples.Tuple
 	Direction *tuples.Tuple
 }
 
 func InitRay(o, d *tuples.Tuple) *Ray {
-	r := Ray{ksuid.New(), o, d}
+	r := Ray{o, d}
 	if o.W != 1 {
 		log.Fatal("Attempted to create a ray origin with a non-point")
 	}
@@ -1,6 +1,9 @@
 package shapes
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/segmentio/ksuid"
 	"happymonday.dev/ray-tracer/src/matrix"
 	"happymonday.dev/ray-tracer/src/tuples"
@@ -12,6 +15,24 @@ type Shape interface {
 	Material() *Material
 	Intersect(r *Ray) *Intersections
 	NormalAt(p *tuples.Tuple) *tuples.Tuple
+	Bounds() *Bounds
+	Parent() Shape
+	SetParent(p Shape)
+	// Clone copies the shape, and everything inside it for groups and CSGs,
+	// with new ids and no parent so the copy can be given a parent of its
+	// own. Materials and geometry such as triangle points are shared.
+	Clone() Shape
+	Invalidate()
+	watch(c *BVHCache)
+	unwatch(c *BVHCache)
+	WorldToObject(p *tuples.Tuple) *tuples.Tuple
+	NormalToWorld(n *tuples.Tuple) *tuples.Tuple
+}
+
+// HitNormaler is implemented by shapes whose normal depends on where they were
+// hit, not just the point, like SmoothTriangle interpolating by U and V.
+type HitNormaler interface {
+	NormalAtHit(p *tuples.Tuple, hit *Intersection) *tuples.Tuple
 }
 
 type ShapeEmbed struct {
@@ -19,6 +40,11 @@ type ShapeEmbed struct {
 	transform        *matrix.Matrix
 	transformInverse *matrix.Matrix
 	material         *Material
+	parent           Shape
+	// watchers are the BVHs holding the shape at their top level, like a
+	// world's, which have no parent to tell when it changes
+	watchers     map[*BVHCache]struct{}
+	watchersLock sync.Mutex
 }
 
 func InitShapeEmbed(t *matrix.Matrix, m *Material) *ShapeEmbed {
@@ -29,10 +55,10 @@ func InitShapeEmbed(t *matrix.Matrix, m *Material) *ShapeEmbed {
 		m = DefaultMaterial()
 	}
 	return &ShapeEmbed{
-		ksuid.New(),
-		t,
-		t.Inverse(),
-		m,
+		Id:               ksuid.New(),
+		transform:        t,
+		transformInverse: t.Inverse(),
+		material:         m,
 	}
 }
 
@@ -47,6 +73,7 @@ func (s *ShapeEmbed) TransformInverse() *matrix.Matrix {
 func (s *ShapeEmbed) SetTransform(t *matrix.Matrix) {
 	s.transform = t
 	s.transformInverse = t.Inverse()
+	s.Invalidate()
 }
 
 func (s *ShapeEmbed) Material() *Material {
@@ -57,16 +84,100 @@ func (s *ShapeEmbed) SetMaterial(m *Material) {
 	s.material = m
 }
 
+// Parent is the group or other composite the shape belongs to, if any.
+func (s *ShapeEmbed) Parent() Shape {
+	return s.parent
+}
+
+func (s *ShapeEmbed) SetParent(p Shape) {
+	s.parent = p
+}
+
+// checkOrphans errors if any of ss already has a parent. A shape finds its
+// place in the world through its one parent so it can't be given a second,
+// a Clone of it can instead.
+func checkOrphans(ss ...Shape) error {
+	for _, s := range ss {
+		if s.Parent() != nil {
+			return fmt.Errorf("%T already has a parent, add a Clone of it instead", s)
+		}
+	}
+	return nil
+}
+
+// clone copies the embed for a new shape sharing the transform and material
+// but with its own id and no parent.
+func (s *ShapeEmbed) clone() *ShapeEmbed {
+	return &ShapeEmbed{
+		Id:               ksuid.New(),
+		transform:        s.transform,
+		transformInverse: s.transformInverse,
+		material:         s.material,
+	}
+}
+
+// Invalidate tells the groups and worlds the shape belongs to that its bounds
+// may have changed so they rebuild their BVHs. SetTransform does this itself,
+// editing fields like a cylinder's Maximum or a triangle's points needs a call.
+func (s *ShapeEmbed) Invalidate() {
+	if s.parent != nil {
+		s.parent.Invalidate()
+	}
+	s.watchersLock.Lock()
+	defer s.watchersLock.Unlock()
+	for c := range s.watchers {
+		c.Invalidate()
+	}
+}
+
+func (s *ShapeEmbed) watch(c *BVHCache) {
+	s.watchersLock.Lock()
+	defer s.watchersLock.Unlock()
+	if s.watchers == nil {
+		s.watchers = map[*BVHCache]struct{}{}
+	}
+	s.watchers[c] = struct{}{}
+}
+
+func (s *ShapeEmbed) unwatch(c *BVHCache) {
+	s.watchersLock.Lock()
+	defer s.watchersLock.Unlock()
+	delete(s.watchers, c)
+}
+
+// WorldToObject converts a world space point into the shape's object space
+// by way of each of its parents' spaces.
+func (s *ShapeEmbed) WorldToObject(p *tuples.Tuple) *tuples.Tuple {
+	if s.parent != nil {
+		p = s.parent.WorldToObject(p)
+	}
+	return s.transformInverse.MultiplyTuple(p)
+}
+
+// NormalToWorld converts an object space normal into world space by way of
+// each of the shape's parents' spaces.
+func (s *ShapeEmbed) NormalToWorld(n *tuples.Tuple) *tuples.Tuple {
+	n = s.transformInverse.Transpose().MultiplyTuple(n)
+	n.W = 0
+	n = n.Normalize()
+	if s.parent != nil {
+		n = s.parent.NormalToWorld(n)
+	}
+	return n
+}
+
 func (s *ShapeEmbed) prepIntersect(r *Ray) *Ray {
 	return r.Transform(s.transformInverse)
 }
 
+func (s *ShapeEmbed) parentSpaceBounds(local *Bounds) *Bounds {
+	return local.Transform(s.transform)
+}
+
 func (s *ShapeEmbed) normalAtPre(p *tuples.Tuple) *tuples.Tuple {
-	return s.TransformInverse().MultiplyTuple(p)
+	return s.WorldToObject(p)
 }
 
 func (s *ShapeEmbed) normalAtPost(localNormal *tuples.Tuple) *tuples.Tuple {
-	worldNormal := s.TransformInverse().Transpose().MultiplyTuple(localNormal)
-	worldNormal.W = 0
-	return worldNormal.Normalize()
+	return s.NormalToWorld(localNormal)
 }
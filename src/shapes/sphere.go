@@ -2,33 +2,23 @@ package shapes
 
 import (
 	"math"
-	"sync"
 
 	"happymonday.dev/ray-tracer/src/tuples"
 )
 
 type Sphere struct {
 	*ShapeEmbed
-	xs sync.Map
 }
 
 func InitSphere() *Sphere {
 	return &Sphere{
 		InitShapeEmbed(nil, nil),
-		sync.Map{},
 	}
 }
 
 func (s Sphere) Intersect(r *Ray) *Intersections {
-	if v, ok := s.xs.Load(r.Id); ok {
-		if xs, ok := v.(*Intersections); ok {
-			return xs
-		}
-	}
-	xs := InitIntersections()
-	s.xs.Store(r.Id, &xs)
-
 	r = s.prepIntersect(r)
+	xs := InitIntersections()
 
 	sphereToRay := r.Origin.Subtract(tuples.InitPoint(0, 0, 0))
 	a := r.Direction.DotProduct(r.Direction)
@@ -54,6 +44,15 @@ func (s Sphere) Equals(s2 any) bool {
 	return false
 }
 
+func (s Sphere) Clone() Shape {
+	s.ShapeEmbed = s.ShapeEmbed.clone()
+	return &s
+}
+
 func (s Sphere) NormalAt(p *tuples.Tuple) *tuples.Tuple {
 	return s.normalAtPost(s.normalAtPre(p).Subtract(tuples.InitPoint(0, 0, 0)))
 }
+
+func (s Sphere) Bounds() *Bounds {
+	return s.parentSpaceBounds(InitBounds(tuples.InitPoint(-1, -1, -1), tuples.InitPoint(1, 1, 1)))
+}
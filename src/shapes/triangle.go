@@ -0,0 +1,155 @@
+package shapes
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+type Triangle struct {
+	*ShapeEmbed
+	P1     *tuples.Tuple
+	P2     *tuples.Tuple
+	P3     *tuples.Tuple
+	E1     *tuples.Tuple
+	E2     *tuples.Tuple
+	Normal *tuples.Tuple
+}
+
+func InitTriangle(p1, p2, p3 *tuples.Tuple) *Triangle {
+	e1 := p2.Subtract(p1)
+	e2 := p3.Subtract(p1)
+	return &Triangle{
+		InitShapeEmbed(nil, nil),
+		p1,
+		p2,
+		p3,
+		e1,
+		e2,
+		e2.CrossProduct(e1).Normalize(),
+	}
+}
+
+func (s Triangle) Intersect(r *Ray) *Intersections {
+	r = s.prepIntersect(r)
+	t, u, v, ok := intersectTriangle(r, s.P1, s.E1, s.E2)
+	if !ok {
+		return InitIntersections()
+	}
+	return InitIntersections(InitIntersectionWithUV(t, s, u, v))
+}
+
+// intersectTriangle is the Möller–Trumbore algorithm. u and v are the
+// barycentric weights of the second and third corners at the hit.
+func intersectTriangle(r *Ray, p1, e1, e2 *tuples.Tuple) (t, u, v float64, ok bool) {
+	dirCrossE2 := r.Direction.CrossProduct(e2)
+	det := e1.DotProduct(dirCrossE2)
+	if math.Abs(det) < maths.EPSILON {
+		return 0, 0, 0, false
+	}
+	f := 1.0 / det
+	p1ToOrigin := r.Origin.Subtract(p1)
+	u = f * p1ToOrigin.DotProduct(dirCrossE2)
+	if u < 0 || u > 1 {
+		return 0, 0, 0, false
+	}
+	originCrossE1 := p1ToOrigin.CrossProduct(e1)
+	v = f * r.Direction.DotProduct(originCrossE1)
+	if v < 0 || u+v > 1 {
+		return 0, 0, 0, false
+	}
+	return f * e2.DotProduct(originCrossE1), u, v, true
+}
+
+func (s Triangle) Equals(s2 any) bool {
+	if v, ok := s2.(Triangle); ok {
+		return s.Id == v.Id
+	}
+	if v, ok := s2.(*Triangle); ok {
+		return s.Id == v.Id
+	}
+	return false
+}
+
+func (s Triangle) Clone() Shape {
+	s.ShapeEmbed = s.ShapeEmbed.clone()
+	return &s
+}
+
+func (s Triangle) NormalAt(p *tuples.Tuple) *tuples.Tuple {
+	return s.normalAtPost(s.Normal)
+}
+
+func (s Triangle) Bounds() *Bounds {
+	return s.parentSpaceBounds(EmptyBounds().AddPoint(s.P1).AddPoint(s.P2).AddPoint(s.P3))
+}
+
+// SmoothTriangle interpolates the normals given for each corner across its
+// face so meshes of them look curved.
+type SmoothTriangle struct {
+	*ShapeEmbed
+	P1 *tuples.Tuple
+	P2 *tuples.Tuple
+	P3 *tuples.Tuple
+	N1 *tuples.Tuple
+	N2 *tuples.Tuple
+	N3 *tuples.Tuple
+	E1 *tuples.Tuple
+	E2 *tuples.Tuple
+}
+
+func InitSmoothTriangle(p1, p2, p3, n1, n2, n3 *tuples.Tuple) *SmoothTriangle {
+	return &SmoothTriangle{
+		InitShapeEmbed(nil, nil),
+		p1,
+		p2,
+		p3,
+		n1,
+		n2,
+		n3,
+		p2.Subtract(p1),
+		p3.Subtract(p1),
+	}
+}
+
+func (s SmoothTriangle) Intersect(r *Ray) *Intersections {
+	r = s.prepIntersect(r)
+	t, u, v, ok := intersectTriangle(r, s.P1, s.E1, s.E2)
+	if !ok {
+		return InitIntersections()
+	}
+	return InitIntersections(InitIntersectionWithUV(t, s, u, v))
+}
+
+func (s SmoothTriangle) Equals(s2 any) bool {
+	if v, ok := s2.(SmoothTriangle); ok {
+		return s.Id == v.Id
+	}
+	if v, ok := s2.(*SmoothTriangle); ok {
+		return s.Id == v.Id
+	}
+	return false
+}
+
+func (s SmoothTriangle) Clone() Shape {
+	s.ShapeEmbed = s.ShapeEmbed.clone()
+	return &s
+}
+
+// NormalAt without a hit has nothing to interpolate with and gives the normal
+// at the first corner.
+func (s SmoothTriangle) NormalAt(p *tuples.Tuple) *tuples.Tuple {
+	return s.normalAtPost(s.N1)
+}
+
+func (s SmoothTriangle) NormalAtHit(p *tuples.Tuple, hit *Intersection) *tuples.Tuple {
+	n := s.N2.MultiplyScalar(hit.U).
+		Add(s.N3.MultiplyScalar(hit.V)).
+		Add(s.N1.MultiplyScalar(1 - hit.U - hit.V))
+	return s.normalAtPost(n)
+}
+
+func (s SmoothTriangle) Bounds() *Bounds {
+	return s.parentSpaceBounds(EmptyBounds().AddPoint(s.P1).AddPoint(s.P2).AddPoint(s.P3))
+}
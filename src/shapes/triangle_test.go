@@ -0,0 +1,103 @@
+package shapes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/tuples"
+)
+
+func testTriangle() *Triangle {
+	return InitTriangle(tuples.InitPoint(0, 1, 0), tuples.InitPoint(-1, 0, 0), tuples.InitPoint(1, 0, 0))
+}
+
+func testSmoothTriangle() *SmoothTriangle {
+	return InitSmoothTriangle(
+		tuples.InitPoint(0, 1, 0),
+		tuples.InitPoint(-1, 0, 0),
+		tuples.InitPoint(1, 0, 0),
+		tuples.InitVector(0, 1, 0),
+		tuples.InitVector(-1, 0, 0),
+		tuples.InitVector(1, 0, 0),
+	)
+}
+
+func TestConstructingATriangle(t *testing.T) {
+	tri := testTriangle()
+	assert.True(t, tuples.InitVector(-1, -1, 0).Equals(tri.E1))
+	assert.True(t, tuples.InitVector(1, -1, 0).Equals(tri.E2))
+	assert.True(t, tuples.InitVector(0, 0, -1).Equals(tri.Normal))
+}
+
+func TestNormalOnATriangle(t *testing.T) {
+	tri := testTriangle()
+	for _, p := range []*tuples.Tuple{
+		tuples.InitPoint(0, 0.5, 0),
+		tuples.InitPoint(-0.5, 0.75, 0),
+		tuples.InitPoint(0.5, 0.25, 0),
+	} {
+		assert.True(t, tri.Normal.Equals(tri.NormalAt(p)))
+	}
+}
+
+func TestARayMissesATriangle(t *testing.T) {
+	type opt struct {
+		s string
+		r *Ray
+	}
+	opts := []opt{
+		{s: "parallel", r: InitRay(tuples.InitPoint(0, -1, -2), tuples.InitVector(0, 1, 0))},
+		{s: "past the p1-p3 edge", r: InitRay(tuples.InitPoint(1, 1, -2), tuples.InitVector(0, 0, 1))},
+		{s: "past the p1-p2 edge", r: InitRay(tuples.InitPoint(-1, 1, -2), tuples.InitVector(0, 0, 1))},
+		{s: "past the p2-p3 edge", r: InitRay(tuples.InitPoint(0, -1, -2), tuples.InitVector(0, 0, 1))},
+	}
+	for _, o := range opts {
+		assert.Empty(t, testTriangle().Intersect(o.r).Intersections, o.s)
+	}
+}
+
+func TestARayStrikesATriangle(t *testing.T) {
+	tri := testTriangle()
+	r := InitRay(tuples.InitPoint(0, 0.5, -2), tuples.InitVector(0, 0, 1))
+	xs := tri.Intersect(r)
+	assert.Equal(t, 1, len(xs.Intersections))
+	assert.Equal(t, 2.0, xs.Intersections[0].T)
+	assert.True(t, tri.Equals(xs.Hit().Object))
+}
+
+func TestTriangleBounds(t *testing.T) {
+	b := testTriangle().Bounds()
+	assert.True(t, tuples.InitPoint(-1, 0, 0).Equals(b.Min))
+	assert.True(t, tuples.InitPoint(1, 1, 0).Equals(b.Max))
+}
+
+func TestAnIntersectionCanEncapsulateUAndV(t *testing.T) {
+	s := testTriangle()
+	i := InitIntersectionWithUV(3.5, s, 0.2, 0.4)
+	assert.Equal(t, 0.2, i.U)
+	assert.Equal(t, 0.4, i.V)
+}
+
+func TestIntersectionWithASmoothTriangleStoresUAndV(t *testing.T) {
+	tri := testSmoothTriangle()
+	r := InitRay(tuples.InitPoint(-0.2, 0.3, -2), tuples.InitVector(0, 0, 1))
+	xs := tri.Intersect(r)
+	assert.True(t, maths.FuzzyEquals(0.45, xs.Intersections[0].U))
+	assert.True(t, maths.FuzzyEquals(0.25, xs.Intersections[0].V))
+}
+
+func TestASmoothTriangleUsesUAndVToInterpolateTheNormal(t *testing.T) {
+	tri := testSmoothTriangle()
+	i := InitIntersectionWithUV(1, tri, 0.45, 0.25)
+	n := tri.NormalAtHit(tuples.InitPoint(0, 0, 0), i)
+	assert.True(t, tuples.InitVector(-0.5547, 0.83205, 0).Equals(n))
+}
+
+func TestPreparingTheNormalOnASmoothTriangle(t *testing.T) {
+	tri := testSmoothTriangle()
+	i := InitIntersectionWithUV(1, tri, 0.45, 0.25)
+	r := InitRay(tuples.InitPoint(-0.2, 0.3, -2), tuples.InitVector(0, 0, 1))
+	comps := i.PrepareComputations(r, InitIntersections(i))
+	assert.True(t, tuples.InitVector(-0.5547, 0.83205, 0).Equals(comps.NormalV))
+}
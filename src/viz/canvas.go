@@ -1,6 +1,7 @@
 package viz
 
 import (
+	"fmt"
 	"image"
 	"image/color"
 	"math"
@@ -26,6 +27,42 @@ func InitCanvas(w, h int) Canvas {
 	return Canvas{Height: h, Width: w, pixels: ps}
 }
 
+// MaxImagePixels is the largest image, in pixels, the readers accept. Bigger
+// sizes are rejected from the header before anything is allocated.
+var MaxImagePixels = 1 << 26
+
+// checkImageSize rejects empty images and those over MaxImagePixels without
+// overflowing on huge dimensions.
+func checkImageSize(w, h int) error {
+	if w < 1 || h < 1 {
+		return fmt.Errorf("image size %dx%d is empty", w, h)
+	}
+	if w > MaxImagePixels/h {
+		return fmt.Errorf("image size %dx%d is over %d pixels", w, h, MaxImagePixels)
+	}
+	return nil
+}
+
+// canvasFromRows wraps rows of pixels read one at a time, so readers only
+// allocate for the pixels their input actually holds.
+func canvasFromRows(w int, rows [][]*Color) *Canvas {
+	return &Canvas{Height: len(rows), Width: w, pixels: rows}
+}
+
+// CanvasFromImage copies an image into a canvas, scaling each channel to 0..1
+// and dropping alpha.
+func CanvasFromImage(img image.Image) Canvas {
+	b := img.Bounds()
+	c := InitCanvas(b.Dx(), b.Dy())
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			p := color.NRGBA64Model.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA64)
+			c.SetPixel(InitColor(float64(p.R)/0xffff, float64(p.G)/0xffff, float64(p.B)/0xffff), x, y)
+		}
+	}
+	return c
+}
+
 func (c *Canvas) Pixel(x, y int) *Color {
 	return c.pixels[y][x]
 }
@@ -42,6 +79,10 @@ func ScaledColorValueRGBA(v float64) int {
 	return int(math.Round(math.Max(math.Min(v*0xff, 0xff), 0)))
 }
 
+func ScaledColorValue65535(v float64) int {
+	return int(math.Round(math.Max(math.Min(v*0xffff, 0xffff), 0)))
+}
+
 func (c *Canvas) DrawRGBA() image.RGBA64Image {
 	img := image.NewRGBA64(image.Rect(0, 0, int(c.Width), int(c.Height)))
 	wg := sync.WaitGroup{}
@@ -59,11 +100,11 @@ func (c *Canvas) DrawRGBA() image.RGBA64Image {
 				img.Set(
 					x,
 					y,
-					color.RGBA{
-						uint8(pc.RRGBA()),
-						uint8(pc.GRGBA()),
-						uint8(pc.BRGBA()),
-						0xff,
+					color.RGBA64{
+						uint16(ScaledColorValue65535(pc.R())),
+						uint16(ScaledColorValue65535(pc.G())),
+						uint16(ScaledColorValue65535(pc.B())),
+						0xffff,
 					},
 				)
 				bar.Add(1)
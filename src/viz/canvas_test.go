@@ -1,6 +1,8 @@
 package viz
 
 import (
+	"image"
+	"image/color"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,3 +31,25 @@ func TestSetColor(t *testing.T) {
 
 	assert.True(t, c.Pixel(2, 3).Equals(red))
 }
+
+func TestCanvasFromImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(0, 0, color.NRGBA{0xff, 0, 0, 0xff})
+	img.Set(2, 1, color.NRGBA{0, 0x33, 0xff, 0xff})
+	img.Set(1, 0, color.NRGBA{0xff, 0xff, 0xff, 0x80})
+	c := CanvasFromImage(img)
+	assert.Equal(t, 3, c.Width)
+	assert.Equal(t, 2, c.Height)
+	assert.True(t, c.Pixel(0, 0).Equals(InitColor(1, 0, 0)))
+	assert.True(t, c.Pixel(2, 1).Equals(InitColor(0, 0.2, 1)))
+	assert.True(t, c.Pixel(1, 0).Equals(InitColor(1, 1, 1)))
+	assert.True(t, c.Pixel(1, 1).Equals(InitColor(0, 0, 0)))
+}
+
+func TestDrawRGBAKeeps16BitPrecision(t *testing.T) {
+	c := InitCanvas(2, 1)
+	c.SetPixel(InitColor(0.5, 0.001, 2), 0, 0)
+	img := c.DrawRGBA()
+	assert.Equal(t, color.RGBA64{0x8000, 0x0042, 0xffff, 0xffff}, img.RGBA64At(0, 0))
+	assert.Equal(t, color.RGBA64{0, 0, 0, 0xffff}, img.RGBA64At(1, 0))
+}
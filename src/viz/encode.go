@@ -0,0 +1,101 @@
+package viz
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+type ImageFormat string
+
+const (
+	FormatJPEG  ImageFormat = "jpeg"
+	FormatPNG   ImageFormat = "png"
+	FormatPNG16 ImageFormat = "png16"
+	FormatPPM   ImageFormat = "ppm"
+	FormatHDR   ImageFormat = "hdr"
+	FormatPFM   ImageFormat = "pfm"
+)
+
+func ParseImageFormat(s string) (ImageFormat, error) {
+	switch f := ImageFormat(s); f {
+	case FormatJPEG, FormatPNG, FormatPNG16, FormatPPM, FormatHDR, FormatPFM:
+		return f, nil
+	case "jpg":
+		return FormatJPEG, nil
+	}
+	return "", fmt.Errorf("unknown image format %q", s)
+}
+
+func (f ImageFormat) ContentType() string {
+	switch f {
+	case FormatPNG, FormatPNG16:
+		return "image/png"
+	case FormatPPM:
+		return "image/x-portable-pixmap"
+	case FormatHDR:
+		return "image/vnd.radiance"
+	case FormatPFM:
+		return "image/x-portable-floatmap"
+	}
+	return "image/jpeg"
+}
+
+// EncodeCanvas writes the canvas in the given format. PPM output is binary,
+// HDR and PFM keep colors outside 0..1.
+func EncodeCanvas(w io.Writer, c *Canvas, f ImageFormat) error {
+	switch f {
+	case FormatPNG:
+		return EncodePNG(w, c, PNGDepth8)
+	case FormatPNG16:
+		return EncodePNG(w, c, PNGDepth16)
+	case FormatPPM:
+		return WritePPMBinary(w, c)
+	case FormatHDR:
+		return WriteHDR(w, c)
+	case FormatPFM:
+		return WritePFM(w, c)
+	case FormatJPEG:
+		return EncodeImage(w, c.DrawRGBA(), f)
+	}
+	return fmt.Errorf("unknown image format %q", string(f))
+}
+
+// EncodeCanvasWith post processes the canvas before encoding it. HDR and PFM
+// are left linear since they exist to keep the unprocessed radiance.
+func EncodeCanvasWith(w io.Writer, c *Canvas, f ImageFormat, p PostProcess) error {
+	if f.HighDynamicRange() {
+		return EncodeCanvas(w, c, f)
+	}
+	return EncodeCanvas(w, p.Apply(c), f)
+}
+
+// HighDynamicRange reports whether the format keeps colors outside 0..1.
+func (f ImageFormat) HighDynamicRange() bool {
+	return f == FormatHDR || f == FormatPFM
+}
+
+// EncodeImage writes an already drawn image in the given format.
+func EncodeImage(w io.Writer, img image.Image, f ImageFormat) error {
+	switch f {
+	case FormatJPEG:
+		return jpeg.Encode(w, img, nil)
+	case FormatPNG:
+		b := img.Bounds()
+		img8 := image.NewNRGBA(b)
+		draw.Draw(img8, b, img, b.Min, draw.Src)
+		return png.Encode(w, img8)
+	case FormatPNG16:
+		b := img.Bounds()
+		img16 := image.NewNRGBA64(b)
+		draw.Draw(img16, b, img, b.Min, draw.Src)
+		return png.Encode(w, img16)
+	case FormatPPM, FormatHDR, FormatPFM:
+		c := CanvasFromImage(img)
+		return EncodeCanvas(w, &c, f)
+	}
+	return fmt.Errorf("unknown image format %q", string(f))
+}
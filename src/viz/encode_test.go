@@ -0,0 +1,82 @@
+package viz
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImageFormat(t *testing.T) {
+	type opt struct {
+		s   string
+		exp ImageFormat
+	}
+	opts := []opt{
+		{"jpeg", FormatJPEG},
+		{"jpg", FormatJPEG},
+		{"png", FormatPNG},
+		{"png16", FormatPNG16},
+		{"ppm", FormatPPM},
+		{"hdr", FormatHDR},
+		{"pfm", FormatPFM},
+	}
+	for _, o := range opts {
+		f, err := ParseImageFormat(o.s)
+		assert.Nil(t, err, o.s)
+		assert.Equal(t, o.exp, f, o.s)
+	}
+	_, err := ParseImageFormat("bmp")
+	assert.ErrorContains(t, err, "unknown image format")
+}
+
+func TestEncodeCanvasInEachFormat(t *testing.T) {
+	type opt struct {
+		f   ImageFormat
+		exp string
+	}
+	opts := []opt{
+		{FormatJPEG, "jpeg"},
+		{FormatPNG, "png"},
+		{FormatPNG16, "png"},
+		{FormatPPM, "ppm"},
+	}
+	c := InitCanvas(4, 4)
+	c.SetPixel(InitColor(1, 1, 1), 2, 2)
+	for _, o := range opts {
+		buf := bytes.Buffer{}
+		assert.Nil(t, EncodeCanvas(&buf, &c, o.f), o.f)
+		img, format, err := image.Decode(&buf)
+		assert.Nil(t, err, o.f)
+		assert.Equal(t, o.exp, format, o.f)
+		assert.Equal(t, image.Rect(0, 0, 4, 4), img.Bounds(), o.f)
+	}
+	assert.NotNil(t, EncodeCanvas(&bytes.Buffer{}, &c, "bmp"))
+
+	buf := bytes.Buffer{}
+	assert.Nil(t, EncodeCanvas(&buf, &c, FormatHDR))
+	_, err := ReadHDR(&buf)
+	assert.Nil(t, err)
+	assert.Nil(t, EncodeCanvas(&buf, &c, FormatPFM))
+	_, err = ReadPFM(&buf)
+	assert.Nil(t, err)
+}
+
+func TestEncodeImageInEachFormat(t *testing.T) {
+	c := InitCanvas(4, 4)
+	img := c.DrawRGBA()
+	for _, f := range []ImageFormat{FormatJPEG, FormatPNG, FormatPNG16, FormatPPM} {
+		buf := bytes.Buffer{}
+		assert.Nil(t, EncodeImage(&buf, img, f), f)
+		_, _, err := image.DecodeConfig(&buf)
+		assert.Nil(t, err, f)
+	}
+}
+
+func TestImageFormatContentTypes(t *testing.T) {
+	assert.Equal(t, "image/jpeg", FormatJPEG.ContentType())
+	assert.Equal(t, "image/png", FormatPNG.ContentType())
+	assert.Equal(t, "image/png", FormatPNG16.ContentType())
+	assert.Equal(t, "image/x-portable-pixmap", FormatPPM.ContentType())
+}
@@ -0,0 +1,184 @@
+package viz
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// WriteHDR streams the canvas to w as a Radiance RGBE (.hdr) image. Colors
+// keep their full range rather than being clamped to 0..1, negative values
+// are written as 0. Scanlines are written uncompressed, which every reader
+// accepts.
+func WriteHDR(w io.Writer, c *Canvas) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y %d +X %d\n", c.Height, c.Width)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			rgbe := toRGBE(c.Pixel(x, y))
+			bw.Write(rgbe[:])
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadHDR reads a Radiance RGBE image with the usual top to bottom, left to
+// right orientation, either uncompressed or run length encoded.
+func ReadHDR(r io.Reader) (*Canvas, error) {
+	br := bufio.NewReader(r)
+	width, height, err := readHDRHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	rows := [][]*Color{}
+	var line [][4]byte
+	for y := 0; y < height; y++ {
+		if line, err = readHDRScanline(br, line[:0], width); err != nil {
+			return nil, fmt.Errorf("hdr: scanline %d: %w", y, err)
+		}
+		// only allocated once the pixels have been read, so a header
+		// claiming a huge width can't make us allocate for it
+		row := make([]*Color, width)
+		for x, rgbe := range line {
+			row[x] = fromRGBE(rgbe)
+		}
+		rows = append(rows, row)
+	}
+	return canvasFromRows(width, rows), nil
+}
+
+func readHDRHeader(br *bufio.Reader) (int, int, error) {
+	magic, err := br.ReadString('\n')
+	if err != nil {
+		return 0, 0, fmt.Errorf("hdr: reading magic number: %w", unexpectedEOF(err))
+	}
+	if magic != "#?RADIANCE\n" && magic != "#?RGBE\n" {
+		return 0, 0, fmt.Errorf("hdr: unsupported magic number %q", strings.TrimSpace(magic))
+	}
+	for {
+		l, err := br.ReadString('\n')
+		if err != nil {
+			return 0, 0, fmt.Errorf("hdr: reading header: %w", unexpectedEOF(err))
+		}
+		l = strings.TrimSpace(l)
+		if l == "" {
+			break
+		}
+		if strings.HasPrefix(l, "FORMAT=") && l != "FORMAT=32-bit_rle_rgbe" {
+			return 0, 0, fmt.Errorf("hdr: unsupported format %q", strings.TrimPrefix(l, "FORMAT="))
+		}
+	}
+
+	res, err := br.ReadString('\n')
+	if err != nil {
+		return 0, 0, fmt.Errorf("hdr: reading resolution: %w", unexpectedEOF(err))
+	}
+	var width, height int
+	if n, _ := fmt.Sscanf(res, "-Y %d +X %d", &height, &width); n != 2 || width < 1 || height < 1 {
+		return 0, 0, fmt.Errorf("hdr: unsupported resolution %q", strings.TrimSpace(res))
+	}
+	if err := checkImageSize(width, height); err != nil {
+		return 0, 0, fmt.Errorf("hdr: %w", err)
+	}
+	return width, height, nil
+}
+
+// readHDRScanline appends either a run length encoded scanline, which starts
+// with 2 2 and the width, or a flat one to line. Flat pixels are appended as
+// they are read, encoded ones are at most 0x7fff wide.
+func readHDRScanline(br *bufio.Reader, line [][4]byte, width int) ([][4]byte, error) {
+	start, err := br.Peek(4)
+	if err != nil {
+		return line, unexpectedEOF(err)
+	}
+	if width < 8 || width > 0x7fff || start[0] != 2 || start[1] != 2 || start[2]&0x80 != 0 {
+		for x := 0; x < width; x++ {
+			var rgbe [4]byte
+			if _, err := io.ReadFull(br, rgbe[:]); err != nil {
+				return line, unexpectedEOF(err)
+			}
+			line = append(line, rgbe)
+		}
+		return line, nil
+	}
+	if int(start[2])<<8|int(start[3]) != width {
+		return line, fmt.Errorf("run length encoded width %d, want %d", int(start[2])<<8|int(start[3]), width)
+	}
+	br.Discard(4)
+	line = append(line, make([][4]byte, width)...)
+
+	// Each channel is encoded separately as runs and literal spans.
+	for ch := 0; ch < 4; ch++ {
+		for x := 0; x < width; {
+			count, err := br.ReadByte()
+			if err != nil {
+				return line, unexpectedEOF(err)
+			}
+			if count > 128 {
+				n := int(count) - 128
+				v, err := br.ReadByte()
+				if err != nil {
+					return line, unexpectedEOF(err)
+				}
+				if x+n > width {
+					return line, fmt.Errorf("run overflows scanline")
+				}
+				for ; n > 0; n-- {
+					line[x][ch] = v
+					x++
+				}
+				continue
+			}
+			n := int(count)
+			if n == 0 || x+n > width {
+				return line, fmt.Errorf("bad span length %d", n)
+			}
+			for ; n > 0; n-- {
+				v, err := br.ReadByte()
+				if err != nil {
+					return line, unexpectedEOF(err)
+				}
+				line[x][ch] = v
+				x++
+			}
+		}
+	}
+	return line, nil
+}
+
+// toRGBE stores each channel as an 8 bit mantissa sharing the exponent of the
+// brightest channel. Colors too bright for the exponent byte, including
+// infinity, saturate at the largest value RGBE holds and NaN is written as
+// black.
+func toRGBE(c *Color) [4]byte {
+	if math.IsNaN(c.R()) || math.IsNaN(c.G()) || math.IsNaN(c.B()) {
+		return [4]byte{}
+	}
+	r, g, b := math.Max(c.R(), 0), math.Max(c.G(), 0), math.Max(c.B(), 0)
+	v := math.Max(r, math.Max(g, b))
+	if v < 1e-32 {
+		return [4]byte{}
+	}
+	m, e := math.Frexp(v)
+	if e > 127 || math.IsInf(v, 1) {
+		scale := math.Ldexp(1, 8-127)
+		return [4]byte{
+			byte(math.Min(r*scale, 255)),
+			byte(math.Min(g*scale, 255)),
+			byte(math.Min(b*scale, 255)),
+			255,
+		}
+	}
+	scale := m * 256 / v
+	return [4]byte{byte(r * scale), byte(g * scale), byte(b * scale), byte(e + 128)}
+}
+
+func fromRGBE(rgbe [4]byte) *Color {
+	if rgbe[3] == 0 {
+		return Black()
+	}
+	f := math.Ldexp(1, int(rgbe[3])-(128+8))
+	return InitColor(float64(rgbe[0])*f, float64(rgbe[1])*f, float64(rgbe[2])*f)
+}
@@ -0,0 +1,141 @@
+package viz
+
+import (
+	"bytes"
+	"math"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertColorNear checks each channel to a relative tolerance, since RGBE
+// only keeps 8 bits of mantissa.
+func assertColorNear(t *testing.T, exp, act *Color, tolerance float64, msg string) {
+	for _, v := range [][2]float64{{exp.R(), act.R()}, {exp.G(), act.G()}, {exp.B(), act.B()}} {
+		assert.InDelta(t, v[0], v[1], tolerance*math.Max(math.Abs(v[0]), 1), msg)
+	}
+}
+
+func TestWriteHDRHeader(t *testing.T) {
+	c := InitCanvas(3, 2)
+	buf := bytes.Buffer{}
+	assert.Nil(t, WriteHDR(&buf, &c))
+	header := "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y 2 +X 3\n"
+	assert.Equal(t, header, buf.String()[:len(header)])
+	assert.Equal(t, len(header)+3*2*4, buf.Len())
+}
+
+func TestRGBEEncoding(t *testing.T) {
+	type opt struct {
+		c   *Color
+		exp [4]byte
+	}
+	opts := []opt{
+		{InitColor(0, 0, 0), [4]byte{0, 0, 0, 0}},
+		{InitColor(1, 0.5, 0.25), [4]byte{128, 64, 32, 129}},
+		{InitColor(-1, 0, 0), [4]byte{0, 0, 0, 0}},
+		{InitColor(8, 0, 4), [4]byte{128, 0, 64, 132}},
+	}
+	for _, o := range opts {
+		assert.Equal(t, o.exp, toRGBE(o.c))
+		assert.True(t, InitColor(math.Max(o.c.R(), 0), o.c.G(), o.c.B()).Equals(fromRGBE(o.exp)))
+	}
+}
+
+func TestRGBEEncodingOutOfRangeValues(t *testing.T) {
+	brightest := math.Ldexp(255, 127-8)
+	type opt struct {
+		s   string
+		c   *Color
+		exp *Color
+	}
+	opts := []opt{
+		{"too bright", InitColor(math.Ldexp(1, 130), 0, 1), InitColor(brightest, 0, 0)},
+		{"largest exponent", InitColor(math.Ldexp(1, 126), 0, 0), InitColor(math.Ldexp(1, 126), 0, 0)},
+		{"infinity", InitColor(math.Inf(1), 1, 0), InitColor(brightest, 0, 0)},
+		{"nan", InitColor(math.NaN(), 1, 1), Black()},
+	}
+	for _, o := range opts {
+		c := InitCanvas(1, 1)
+		c.SetPixel(o.c, 0, 0)
+		buf := bytes.Buffer{}
+		assert.Nil(t, WriteHDR(&buf, &c), o.s)
+		c2, err := ReadHDR(&buf)
+		assert.Nil(t, err, o.s)
+		assertColorNear(t, o.exp, c2.Pixel(0, 0), 0.01, o.s)
+	}
+}
+
+func TestHDRRoundTripKeepsHighDynamicRange(t *testing.T) {
+	c := InitCanvas(10, 3)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			c.SetPixel(InitColor(float64(x)*10, 0.001*float64(y+1), 0.5), x, y)
+		}
+	}
+	buf := bytes.Buffer{}
+	assert.Nil(t, WriteHDR(&buf, &c))
+	c2, err := ReadHDR(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, c.Width, c2.Width)
+	assert.Equal(t, c.Height, c2.Height)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			assertColorNear(t, c.Pixel(x, y), c2.Pixel(x, y), 0.01, "pixel")
+		}
+	}
+}
+
+func TestReadingARunLengthEncodedHDR(t *testing.T) {
+	data := []byte("#?RADIANCE\n# made by hand\nFORMAT=32-bit_rle_rgbe\nEXPOSURE=1\n\n-Y 1 +X 8\n")
+	data = append(data, 2, 2, 0, 8)
+	// Red is one run of 128, green a literal span, blue zero and the exponent
+	// a run of 129.
+	data = append(data, 128+8, 128)
+	data = append(data, 8, 0, 32, 64, 96, 128, 160, 192, 224)
+	data = append(data, 128+8, 0)
+	data = append(data, 128+8, 129)
+	c, err := ReadHDR(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.True(t, InitColor(1, 0, 0).Equals(c.Pixel(0, 0)))
+	assert.True(t, InitColor(1, 0.5, 0).Equals(c.Pixel(2, 0)))
+	assert.True(t, InitColor(1, 1.75, 0).Equals(c.Pixel(7, 0)))
+}
+
+func TestReadingMalformedHDRs(t *testing.T) {
+	type opt struct {
+		s   string
+		hdr string
+		exp string
+	}
+	opts := []opt{
+		{"empty", "", "magic number"},
+		{"wrong magic", "P3\n", "magic number"},
+		{"unterminated header", "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n", "unexpected EOF"},
+		{"xyze", "#?RADIANCE\nFORMAT=32-bit_rle_xyze\n\n-Y 1 +X 1\n", "unsupported format"},
+		{"flipped", "#?RADIANCE\n\n+Y 1 +X 1\n\x00\x00\x00\x00", "unsupported resolution"},
+		{"truncated pixels", "#?RADIANCE\n\n-Y 1 +X 2\n\x00\x00\x00\x00", "unexpected EOF"},
+		{"rle width", "#?RADIANCE\n\n-Y 1 +X 8\n\x02\x02\x00\x09", "width 9"},
+		{"rle overflow", "#?RADIANCE\n\n-Y 1 +X 8\n\x02\x02\x00\x08\x89\x00", "overflows"},
+		{"too many pixels", "#?RADIANCE\n\n-Y 1073741824 +X 1073741824\n\x00\x00\x00\x00", "over 67108864 pixels"},
+		{"huge size with few pixels", "#?RADIANCE\n\n-Y 8000 +X 8000\n\x00\x00\x00\x00", "unexpected EOF"},
+		{"huge width with few pixels", "#?RADIANCE\n\n-Y 1 +X 67108864\n\x00\x00\x00\x00", "unexpected EOF"},
+	}
+	for _, o := range opts {
+		_, err := ReadHDR(bytes.NewBufferString(o.hdr))
+		assert.ErrorContains(t, err, o.exp, o.s)
+	}
+}
+
+func TestReadingAHugeHDRWidthAllocatesForThePixelsRead(t *testing.T) {
+	hdr := "#?RADIANCE\n\n-Y 1 +X 67108864\n" + strings.Repeat("\x00", 4000)
+	before := runtime.MemStats{}
+	runtime.ReadMemStats(&before)
+	_, err := ReadHDR(bytes.NewBufferString(hdr))
+	after := runtime.MemStats{}
+	runtime.ReadMemStats(&after)
+	assert.ErrorContains(t, err, "unexpected EOF")
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20))
+}
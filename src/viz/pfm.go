@@ -0,0 +1,98 @@
+package viz
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// WritePFM streams the canvas to w as a little endian color Portable Float
+// Map, which stores every channel as a 32 bit float. Rows are written bottom
+// to top as the format requires.
+func WritePFM(w io.Writer, c *Canvas) error {
+	bw := bufio.NewWriter(w)
+	// A negative scale marks the data as little endian.
+	fmt.Fprintf(bw, "PF\n%d %d\n-1.0\n", c.Width, c.Height)
+	buf := make([]byte, 12)
+	for y := c.Height - 1; y >= 0; y-- {
+		for x := 0; x < c.Width; x++ {
+			p := c.Pixel(x, y)
+			binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(float32(p.R())))
+			binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(float32(p.G())))
+			binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(float32(p.B())))
+			bw.Write(buf)
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadPFM reads a color (PF) or greyscale (Pf) Portable Float Map in either
+// byte order.
+func ReadPFM(r io.Reader) (*Canvas, error) {
+	p := ppmReader{bufio.NewReader(r)}
+	magic, err := p.token()
+	if err != nil {
+		return nil, fmt.Errorf("pfm: reading magic number: %w", err)
+	}
+	channels := 3
+	switch magic {
+	case "PF":
+	case "Pf":
+		channels = 1
+	default:
+		return nil, fmt.Errorf("pfm: unsupported magic number %q", magic)
+	}
+
+	dims := [2]int{}
+	for i, name := range []string{"width", "height"} {
+		tok, err := p.token()
+		if err != nil {
+			return nil, fmt.Errorf("pfm: reading %s: %w", name, err)
+		}
+		dims[i], err = strconv.Atoi(tok)
+		if err != nil || dims[i] < 1 {
+			return nil, fmt.Errorf("pfm: invalid %s %q", name, tok)
+		}
+	}
+	tok, err := p.token()
+	if err != nil {
+		return nil, fmt.Errorf("pfm: reading scale: %w", err)
+	}
+	scale, err := strconv.ParseFloat(tok, 64)
+	if err != nil || scale == 0 {
+		return nil, fmt.Errorf("pfm: invalid scale %q", tok)
+	}
+	var order binary.ByteOrder = binary.BigEndian
+	if scale < 0 {
+		order = binary.LittleEndian
+	}
+
+	width, height := dims[0], dims[1]
+	if err := checkImageSize(width, height); err != nil {
+		return nil, fmt.Errorf("pfm: %w", err)
+	}
+	rows := [][]*Color{}
+	buf := make([]byte, 4*channels)
+	for y := height - 1; y >= 0; y-- {
+		row := []*Color{}
+		for x := 0; x < width; x++ {
+			if _, err := io.ReadFull(p.r, buf); err != nil {
+				return nil, fmt.Errorf("pfm: pixel (%d, %d): %w", x, y, unexpectedEOF(err))
+			}
+			v := [3]float64{}
+			for i := range v {
+				v[i] = float64(math.Float32frombits(order.Uint32(buf[4*(i%channels):])))
+			}
+			row = append(row, InitColor(v[0], v[1], v[2]))
+		}
+		rows = append(rows, row)
+	}
+	// rows were read bottom to top
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+	return canvasFromRows(width, rows), nil
+}
@@ -0,0 +1,67 @@
+package viz
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPFMRoundTripKeepsHighDynamicRange(t *testing.T) {
+	c := InitCanvas(3, 2)
+	c.SetPixel(InitColor(100.5, -2, 0.001), 0, 0)
+	c.SetPixel(InitColor(1, 2, 3), 2, 1)
+	buf := bytes.Buffer{}
+	assert.Nil(t, WritePFM(&buf, &c))
+	assert.Equal(t, "PF\n3 2\n-1.0\n", buf.String()[:12])
+	assert.Equal(t, 12+3*2*12, buf.Len())
+
+	c2, err := ReadPFM(&buf)
+	assert.Nil(t, err)
+	assert.True(t, c.Pixel(0, 0).Equals(c2.Pixel(0, 0)))
+	assert.True(t, c.Pixel(2, 1).Equals(c2.Pixel(2, 1)))
+	assert.True(t, Black().Equals(c2.Pixel(1, 1)))
+}
+
+func TestPFMRowsAreStoredBottomToTop(t *testing.T) {
+	c := InitCanvas(1, 2)
+	c.SetPixel(InitColor(1, 1, 1), 0, 1)
+	buf := bytes.Buffer{}
+	assert.Nil(t, WritePFM(&buf, &c))
+	first := math.Float32frombits(binary.LittleEndian.Uint32(buf.Bytes()[12:]))
+	assert.Equal(t, float32(1), first)
+}
+
+func TestReadingABigEndianGreyscalePFM(t *testing.T) {
+	data := []byte("Pf\n2 1\n1.0\n")
+	for _, v := range []float32{0.25, 4} {
+		data = binary.BigEndian.AppendUint32(data, math.Float32bits(v))
+	}
+	c, err := ReadPFM(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.True(t, InitColor(0.25, 0.25, 0.25).Equals(c.Pixel(0, 0)))
+	assert.True(t, InitColor(4, 4, 4).Equals(c.Pixel(1, 0)))
+}
+
+func TestReadingMalformedPFMs(t *testing.T) {
+	type opt struct {
+		s   string
+		pfm string
+		exp string
+	}
+	opts := []opt{
+		{"empty", "", "magic number"},
+		{"wrong magic", "P6\n1 1\n-1\n", "magic number"},
+		{"bad width", "PF\n-1 1\n-1\n", "invalid width"},
+		{"zero scale", "PF\n1 1\n0\n", "invalid scale"},
+		{"truncated", "PF\n1 1\n-1\n\x00\x00", "unexpected EOF"},
+		{"too many pixels", "PF\n1073741824 1073741824\n-1\n\x00\x00", "over 67108864 pixels"},
+		{"huge size with few pixels", "PF\n8000 8000\n-1\n\x00\x00\x00\x00", "unexpected EOF"},
+	}
+	for _, o := range opts {
+		_, err := ReadPFM(bytes.NewBufferString(o.pfm))
+		assert.ErrorContains(t, err, o.exp, o.s)
+	}
+}
@@ -0,0 +1,49 @@
+package viz
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+type PNGDepth int
+
+const (
+	PNGDepth8  PNGDepth = 8
+	PNGDepth16 PNGDepth = 16
+)
+
+// EncodePNG writes the canvas as an opaque RGB PNG with 8 or 16 bits per
+// channel, quantising the float colors directly to that depth.
+func EncodePNG(w io.Writer, c *Canvas, depth PNGDepth) error {
+	if depth != PNGDepth8 && depth != PNGDepth16 {
+		return fmt.Errorf("unsupported PNG depth %d", depth)
+	}
+	rect := image.Rect(0, 0, c.Width, c.Height)
+	if depth == PNGDepth16 {
+		img := image.NewNRGBA64(rect)
+		for y := 0; y < c.Height; y++ {
+			for x := 0; x < c.Width; x++ {
+				p := c.Pixel(x, y)
+				img.SetNRGBA64(x, y, color.NRGBA64{
+					uint16(ScaledColorValue65535(p.R())),
+					uint16(ScaledColorValue65535(p.G())),
+					uint16(ScaledColorValue65535(p.B())),
+					0xffff,
+				})
+			}
+		}
+		return png.Encode(w, img)
+	}
+
+	img := image.NewNRGBA(rect)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			p := c.Pixel(x, y)
+			img.SetNRGBA(x, y, color.NRGBA{uint8(p.R256()), uint8(p.G256()), uint8(p.B256()), 0xff})
+		}
+	}
+	return png.Encode(w, img)
+}
@@ -0,0 +1,44 @@
+package viz
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodePNG(t *testing.T) {
+	type opt struct {
+		depth PNGDepth
+		model color.Model
+		exp   color.Color
+	}
+	opts := []opt{
+		{PNGDepth8, color.RGBAModel, color.RGBA{0x80, 0, 0xff, 0xff}},
+		{PNGDepth16, color.RGBA64Model, color.RGBA64{0x8000, 0x0042, 0xffff, 0xffff}},
+	}
+	c := InitCanvas(3, 2)
+	c.SetPixel(InitColor(0.5, 0.001, 1.5), 1, 1)
+	for _, o := range opts {
+		buf := bytes.Buffer{}
+		assert.Nil(t, EncodePNG(&buf, &c, o.depth))
+		img, err := png.Decode(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, image.Rect(0, 0, 3, 2), img.Bounds())
+		// Opaque PNGs decode as plain RGB at the depth they were written.
+		assert.Equal(t, o.model, img.ColorModel(), "%d bit", o.depth)
+		assert.Equal(t, o.exp, o.model.Convert(img.At(1, 1)), "%d bit", o.depth)
+	}
+}
+
+func TestEncodePNGWithAnUnsupportedDepth(t *testing.T) {
+	c := InitCanvas(1, 1)
+	buf := bytes.Buffer{}
+	for _, d := range []PNGDepth{0, 4, 32} {
+		assert.ErrorContains(t, EncodePNG(&buf, &c, d), "unsupported PNG depth")
+	}
+	assert.Zero(t, buf.Len())
+}
@@ -1,56 +1,65 @@
 package viz
 
 import (
+	"bufio"
 	"fmt"
-	"log"
+	"io"
+	"strconv"
 	"strings"
-	"sync"
 )
 
 const MaxColorValue = 255
 
+// ppmLineLength is the longest line a plain PPM file may contain.
+const ppmLineLength = 70
+
 func CanvasToPPM(c Canvas) string {
-	res := []string{"P3", fmt.Sprintf("%d %d", c.Width, c.Height), fmt.Sprint(MaxColorValue)}
-	rows := sync.Map{}
-	wg := sync.WaitGroup{}
-	wg.Add(c.Height)
-	for i := 0; i < c.Height; i++ {
-		rowI := i
-		go func() {
-			row := []string{}
-			var curStr string
-			for j := 0; j < c.Width; j++ {
-				r, g, b := ppmScaledColor(c.Pixel(j, rowI))
-				for _, v := range []int{r, g, b} {
-					vStr := fmt.Sprint(v)
-					if len(curStr)+len(vStr) > 70 {
-						row = append(row, strings.TrimSpace(curStr))
-						curStr = vStr + " "
-					} else {
-						curStr += vStr + " "
-					}
-				}
-			}
-			row = append(row, strings.TrimSpace(curStr))
-			rows.Store(rowI, row)
-			wg.Done()
-		}()
-	}
-	wg.Wait()
-	for i := 0; i < c.Height; i++ {
-		v, ok := rows.Load(i)
-		if ok {
-			if row, ok := v.([]string); ok {
-				for _, s := range row {
-					res = append(res, s)
+	sb := strings.Builder{}
+	// Writing to a strings.Builder can't fail.
+	WritePPM(&sb, &c)
+	return sb.String()
+}
+
+// WritePPM streams the canvas to w as a plain text (P3) PPM. Each row starts
+// on a new line and lines are wrapped to stay within 70 characters.
+func WritePPM(w io.Writer, c *Canvas) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P3\n%d %d\n%d\n", c.Width, c.Height, MaxColorValue)
+	buf := []byte{}
+	for y := 0; y < c.Height; y++ {
+		lineLen := 0
+		for x := 0; x < c.Width; x++ {
+			r, g, b := ppmScaledColor(c.Pixel(x, y))
+			for _, v := range []int{r, g, b} {
+				buf = strconv.AppendInt(buf[:0], int64(v), 10)
+				if lineLen > 0 && lineLen+len(buf) > ppmLineLength {
+					bw.WriteByte('\n')
+					lineLen = 0
+				} else if lineLen > 0 {
+					bw.WriteByte(' ')
 				}
+				bw.Write(buf)
+				lineLen += len(buf) + 1
 			}
 		}
-		if !ok {
-			log.Fatal("Map read failed for snapshot", i)
+		bw.WriteByte('\n')
+	}
+	// bufio.Writer keeps the first error, so checking once is enough.
+	return bw.Flush()
+}
+
+// WritePPMBinary streams the canvas to w as a binary (P6) PPM, which is
+// smaller and faster to read and write than P3.
+func WritePPMBinary(w io.Writer, c *Canvas) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P6\n%d %d\n%d\n", c.Width, c.Height, MaxColorValue)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			r, g, b := ppmScaledColor(c.Pixel(x, y))
+			bw.Write([]byte{byte(r), byte(g), byte(b)})
 		}
 	}
-	return strings.Join(res, "\n") + "\n"
+	return bw.Flush()
 }
 
 func ppmScaledColor(c *Color) (int, int, int) {
@@ -0,0 +1,200 @@
+package viz
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	image.RegisterFormat("ppm", "P3", decodePPMImage, decodePPMConfig)
+	image.RegisterFormat("ppm", "P6", decodePPMImage, decodePPMConfig)
+}
+
+// PPMToCanvas parses the output of CanvasToPPM, or any other P3 or P6 file.
+func PPMToCanvas(ppm string) (*Canvas, error) {
+	return ReadPPM(strings.NewReader(ppm))
+}
+
+// ReadPPM reads a plain (P3) or binary (P6) PPM image. Samples are scaled by
+// the file's max value so colors run from 0 to 1.
+func ReadPPM(r io.Reader) (*Canvas, error) {
+	p := ppmReader{bufio.NewReader(r)}
+	h, err := p.header()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]*Color{}
+	for y := 0; y < h.height; y++ {
+		row := []*Color{}
+		for x := 0; x < h.width; x++ {
+			rgb := [3]float64{}
+			for i := range rgb {
+				v, err := p.sample(h)
+				if err != nil {
+					return nil, fmt.Errorf("ppm: pixel (%d, %d): %w", x, y, err)
+				}
+				rgb[i] = float64(v) / float64(h.maxValue)
+			}
+			row = append(row, InitColor(rgb[0], rgb[1], rgb[2]))
+		}
+		rows = append(rows, row)
+	}
+	return canvasFromRows(h.width, rows), nil
+}
+
+type ppmHeader struct {
+	binary   bool
+	width    int
+	height   int
+	maxValue int
+}
+
+type ppmReader struct {
+	r *bufio.Reader
+}
+
+func (p ppmReader) header() (*ppmHeader, error) {
+	magic, err := p.token()
+	if err != nil {
+		return nil, fmt.Errorf("ppm: reading magic number: %w", err)
+	}
+	h := &ppmHeader{}
+	switch magic {
+	case "P3":
+	case "P6":
+		h.binary = true
+	default:
+		return nil, fmt.Errorf("ppm: unsupported magic number %q", magic)
+	}
+
+	for _, f := range []struct {
+		name string
+		v    *int
+		max  int
+	}{
+		{"width", &h.width, 1 << 30},
+		{"height", &h.height, 1 << 30},
+		{"max value", &h.maxValue, 65535},
+	} {
+		tok, err := p.token()
+		if err != nil {
+			return nil, fmt.Errorf("ppm: reading %s: %w", f.name, err)
+		}
+		*f.v, err = strconv.Atoi(tok)
+		if err != nil || *f.v < 1 || *f.v > f.max {
+			return nil, fmt.Errorf("ppm: invalid %s %q", f.name, tok)
+		}
+	}
+	if err := checkImageSize(h.width, h.height); err != nil {
+		return nil, fmt.Errorf("ppm: %w", err)
+	}
+	// Reading the max value consumed the single whitespace character that
+	// separates the header from binary pixel data.
+	return h, nil
+}
+
+func (p ppmReader) sample(h *ppmHeader) (int, error) {
+	var v int
+	if h.binary {
+		b, err := p.r.ReadByte()
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		v = int(b)
+		if h.maxValue > 255 {
+			lo, err := p.r.ReadByte()
+			if err != nil {
+				return 0, unexpectedEOF(err)
+			}
+			v = v<<8 | int(lo)
+		}
+	} else {
+		tok, err := p.token()
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		v, err = strconv.Atoi(tok)
+		if err != nil {
+			return 0, fmt.Errorf("invalid sample %q", tok)
+		}
+	}
+	if v < 0 || v > h.maxValue {
+		return 0, fmt.Errorf("sample %d outside 0..%d", v, h.maxValue)
+	}
+	return v, nil
+}
+
+// token returns the next whitespace separated word, skipping comments which
+// run from # to the end of the line.
+func (p ppmReader) token() (string, error) {
+	tok := []byte{}
+	for {
+		b, err := p.r.ReadByte()
+		if err == io.EOF && len(tok) > 0 {
+			return string(tok), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case b == '#':
+			if _, err := p.r.ReadString('\n'); err != nil && err != io.EOF {
+				return "", err
+			}
+			if len(tok) > 0 {
+				return string(tok), nil
+			}
+		case isPPMSpace(b):
+			if len(tok) > 0 {
+				return string(tok), nil
+			}
+		default:
+			tok = append(tok, b)
+		}
+	}
+}
+
+func isPPMSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f'
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func decodePPMImage(r io.Reader) (image.Image, error) {
+	c, err := ReadPPM(r)
+	if err != nil {
+		return nil, err
+	}
+	img := image.NewNRGBA64(image.Rect(0, 0, c.Width, c.Height))
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			p := c.Pixel(x, y)
+			img.SetNRGBA64(x, y, color.NRGBA64{
+				uint16(ScaledColorValue65535(p.R())),
+				uint16(ScaledColorValue65535(p.G())),
+				uint16(ScaledColorValue65535(p.B())),
+				0xffff,
+			})
+		}
+	}
+	return img, nil
+}
+
+func decodePPMConfig(r io.Reader) (image.Config, error) {
+	h, err := ppmReader{bufio.NewReader(r)}.header()
+	if err != nil {
+		return image.Config{}, err
+	}
+	return image.Config{ColorModel: color.NRGBA64Model, Width: h.width, Height: h.height}, nil
+}
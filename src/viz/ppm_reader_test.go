@@ -0,0 +1,138 @@
+package viz
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadingAPPMWithTheWrongMagicNumber(t *testing.T) {
+	_, err := PPMToCanvas("P32\n1 1\n255\n0 0 0\n")
+	assert.ErrorContains(t, err, "magic number")
+}
+
+func TestReadingAPPMSetsWidthAndHeight(t *testing.T) {
+	c, err := PPMToCanvas("P3\n10 2\n255\n" +
+		"0 0 0  0 0 0  0 0 0  0 0 0  0 0 0\n0 0 0  0 0 0  0 0 0  0 0 0  0 0 0\n" +
+		"0 0 0  0 0 0  0 0 0  0 0 0  0 0 0\n0 0 0  0 0 0  0 0 0  0 0 0  0 0 0\n")
+	assert.Nil(t, err)
+	assert.Equal(t, 10, c.Width)
+	assert.Equal(t, 2, c.Height)
+}
+
+func TestReadingPixelDataFromAPPM(t *testing.T) {
+	type opt struct {
+		x   int
+		y   int
+		exp *Color
+	}
+	opts := []opt{
+		{0, 0, InitColor(1, 0.49804, 0)},
+		{1, 0, InitColor(0, 0.49804, 1)},
+		{2, 0, InitColor(0.49804, 1, 0)},
+		{3, 0, InitColor(1, 1, 1)},
+		{0, 1, InitColor(0, 0, 0)},
+		{1, 1, InitColor(1, 0, 0)},
+		{2, 1, InitColor(0, 1, 0)},
+		{3, 1, InitColor(0, 0, 1)},
+		{0, 2, InitColor(1, 1, 0)},
+		{1, 2, InitColor(0, 1, 1)},
+		{2, 2, InitColor(1, 0, 1)},
+		{3, 2, InitColor(0.49804, 0.49804, 0.49804)},
+	}
+	c, err := PPMToCanvas("P3\n4 3\n255\n" +
+		"255 127 0  0 127 255  127 255 0  255 255 255\n" +
+		"0 0 0  255 0 0  0 255 0  0 0 255\n" +
+		"255 255 0  0 255 255  255 0 255  127 127 127\n")
+	assert.Nil(t, err)
+	for _, o := range opts {
+		assert.True(t, o.exp.Equals(c.Pixel(o.x, o.y)), "%d, %d: %v", o.x, o.y, c.Pixel(o.x, o.y))
+	}
+}
+
+func TestReadingAPPMWithCommentsAndOddWhitespace(t *testing.T) {
+	c, err := PPMToCanvas("P3\n# this is a comment\n2 1\n# another\n255\n" +
+		"255 255 255\n# yet another\n0\t0\r\n255   ")
+	assert.Nil(t, err)
+	assert.True(t, InitColor(1, 1, 1).Equals(c.Pixel(0, 0)))
+	assert.True(t, InitColor(0, 0, 1).Equals(c.Pixel(1, 0)))
+}
+
+func TestReadingAPPMAllowsRGBTriplesToSpanLines(t *testing.T) {
+	c, err := PPMToCanvas("P3\n1 1\n255\n51\n153\n\n204\n")
+	assert.Nil(t, err)
+	assert.True(t, InitColor(0.2, 0.6, 0.8).Equals(c.Pixel(0, 0)))
+}
+
+func TestReadingAPPMScalesByTheMaxValue(t *testing.T) {
+	c, err := PPMToCanvas("P3\n2 2\n100\n100 100 100  50 50 50\n75 50 25  0 0 0\n")
+	assert.Nil(t, err)
+	assert.True(t, InitColor(0.75, 0.5, 0.25).Equals(c.Pixel(0, 1)))
+}
+
+func TestReadingABinaryPPM(t *testing.T) {
+	data := append([]byte("P6\n# comment\n2 1\n255\n"), 255, 0, 51, 0, 255, 102)
+	c, err := ReadPPM(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.True(t, InitColor(1, 0, 0.2).Equals(c.Pixel(0, 0)))
+	assert.True(t, InitColor(0, 1, 0.4).Equals(c.Pixel(1, 0)))
+}
+
+func TestReadingASixteenBitBinaryPPM(t *testing.T) {
+	data := append([]byte("P6 1 1 65535\n"), 0xff, 0xff, 0x80, 0x00, 0x00, 0x00)
+	c, err := ReadPPM(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.True(t, InitColor(1, 0.50001, 0).Equals(c.Pixel(0, 0)))
+}
+
+func TestReadingMalformedPPMs(t *testing.T) {
+	type opt struct {
+		s   string
+		ppm string
+		exp string
+	}
+	opts := []opt{
+		{"empty", "", "magic number"},
+		{"bad width", "P3\nten 2\n255\n", "invalid width"},
+		{"zero height", "P3\n1 0\n255\n", "invalid height"},
+		{"missing max value", "P3\n1 1\n", "max value"},
+		{"max value too large", "P3\n1 1\n70000\n", "invalid max value"},
+		{"truncated pixels", "P3\n2 1\n255\n0 0 0 0\n", "unexpected EOF"},
+		{"sample above max value", "P3\n1 1\n100\n0 101 0\n", "outside 0..100"},
+		{"non numeric sample", "P3\n1 1\n255\n0 x 0\n", "invalid sample"},
+		{"truncated binary", "P6\n2 1\n255\n\x00\x00\x00\x00", "unexpected EOF"},
+		{"too many pixels", "P3 1073741824 1073741824 255", "over 67108864 pixels"},
+		{"huge size with few pixels", "P6\n8000 8000\n255\n\x00\x00\x00\x00\x00\x00", "unexpected EOF"},
+	}
+	for _, o := range opts {
+		_, err := PPMToCanvas(o.ppm)
+		assert.ErrorContains(t, err, o.exp, o.s)
+	}
+}
+
+func TestPPMRoundTrip(t *testing.T) {
+	c := InitCanvas(20, 3)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			c.SetPixel(InitColor(float64(x)/19, float64(y)/2, 0.2), x, y)
+		}
+	}
+	c2, err := PPMToCanvas(CanvasToPPM(c))
+	assert.Nil(t, err)
+	assert.Equal(t, CanvasToPPM(c), CanvasToPPM(*c2))
+}
+
+func TestDecodingAHugePPMAsAnImage(t *testing.T) {
+	_, _, err := image.Decode(bytes.NewBufferString("P3 1073741824 1073741824 255 0 0 0"))
+	assert.ErrorContains(t, err, "over 67108864 pixels")
+}
+
+func TestDecodingAPPMAsAnImage(t *testing.T) {
+	img, format, err := image.Decode(bytes.NewBufferString("P3\n1 1\n255\n255 0 255\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, "ppm", format)
+	c := CanvasFromImage(img)
+	assert.True(t, InitColor(1, 0, 1).Equals(c.Pixel(0, 0)))
+}
@@ -1,6 +1,9 @@
 package viz
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"strings"
 	"testing"
 
@@ -40,3 +43,66 @@ func TestCanvasToPPMSplitLongLines(t *testing.T) {
 	pixelData := "255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204\n153 255 204 153 255 204 153 255 204 153 255 204 153\n255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204\n153 255 204 153 255 204 153 255 204 153 255 204 153\n"
 	assert.Equal(t, pixelData, ppm[12:])
 }
+
+func TestWritePPMMatchesCanvasToPPM(t *testing.T) {
+	c := InitCanvas(30, 4)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			c.SetPixel(InitColor(float64(x)/29, 0.5, float64(y)/3), x, y)
+		}
+	}
+	buf := bytes.Buffer{}
+	assert.Nil(t, WritePPM(&buf, &c))
+	assert.Equal(t, CanvasToPPM(c), buf.String())
+	for _, l := range strings.Split(buf.String(), "\n") {
+		assert.LessOrEqual(t, len(l), 70)
+	}
+}
+
+func TestWritePPMBinary(t *testing.T) {
+	c := InitCanvas(2, 1)
+	c.SetPixel(InitColor(1.5, 0, 0.2), 0, 0)
+	c.SetPixel(InitColor(-0.5, 1, 0.4), 1, 0)
+	buf := bytes.Buffer{}
+	assert.Nil(t, WritePPMBinary(&buf, &c))
+	assert.Equal(t, append([]byte("P6\n2 1\n255\n"), 255, 0, 51, 0, 255, 102), buf.Bytes())
+
+	c2, err := ReadPPM(&buf)
+	assert.Nil(t, err)
+	assert.True(t, InitColor(1, 0, 0.2).Equals(c2.Pixel(0, 0)))
+}
+
+// failingWriter accepts n bytes and then errors.
+type failingWriter struct {
+	n int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		written := w.n
+		w.n = 0
+		return written, errors.New("disk full")
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestPPMWritersReturnWriteErrors(t *testing.T) {
+	c := InitCanvas(100, 100)
+	assert.ErrorContains(t, WritePPM(&failingWriter{100}, &c), "disk full")
+	assert.ErrorContains(t, WritePPMBinary(&failingWriter{100}, &c), "disk full")
+}
+
+func BenchmarkCanvasToPPM(b *testing.B) {
+	c := InitCanvas(500, 500)
+	for i := 0; i < b.N; i++ {
+		CanvasToPPM(c)
+	}
+}
+
+func BenchmarkWritePPM(b *testing.B) {
+	c := InitCanvas(500, 500)
+	for i := 0; i < b.N; i++ {
+		WritePPM(io.Discard, &c)
+	}
+}
@@ -0,0 +1,92 @@
+package viz
+
+import (
+	"fmt"
+	"math"
+)
+
+// ToneMap compresses linear scene colors, which can be any brightness, into
+// the 0..1 range displays can show.
+type ToneMap string
+
+const (
+	// ToneMapClamp cuts off anything brighter than 1, the default.
+	ToneMapClamp ToneMap = "clamp"
+	// ToneMapReinhard maps x to x / (1 + x), never quite reaching white.
+	ToneMapReinhard ToneMap = "reinhard"
+	// ToneMapACES is Narkowicz's fit of the ACES filmic curve, which keeps
+	// more contrast in the midtones than Reinhard.
+	ToneMapACES ToneMap = "aces"
+)
+
+func ParseToneMap(s string) (ToneMap, error) {
+	switch t := ToneMap(s); t {
+	case ToneMapClamp, ToneMapReinhard, ToneMapACES:
+		return t, nil
+	case "":
+		return ToneMapClamp, nil
+	}
+	return "", fmt.Errorf("unknown tone map %q", s)
+}
+
+// Channel maps a single non negative channel value.
+func (t ToneMap) Channel(x float64) float64 {
+	x = math.Max(x, 0)
+	switch t {
+	case ToneMapReinhard:
+		return x / (1 + x)
+	case ToneMapACES:
+		x = (x * (2.51*x + 0.03)) / (x*(2.43*x+0.59) + 0.14)
+	}
+	return math.Min(x, 1)
+}
+
+// SRGBEncode applies the sRGB transfer curve to a linear value in 0..1.
+func SRGBEncode(x float64) float64 {
+	if x <= 0.0031308 {
+		return 12.92 * x
+	}
+	return 1.055*math.Pow(x, 1/2.4) - 0.055
+}
+
+// SRGBDecode converts an sRGB encoded value in 0..1 back to linear.
+func SRGBDecode(x float64) float64 {
+	if x <= 0.04045 {
+		return x / 12.92
+	}
+	return math.Pow((x+0.055)/1.055, 2.4)
+}
+
+// PostProcess turns a rendered canvas into display colors: it scales by the
+// exposure, tone maps and optionally sRGB encodes. The zero value clamps and
+// leaves colors otherwise unchanged, matching plain output.
+type PostProcess struct {
+	// Exposure is in stops, each one doubling brightness.
+	Exposure float64
+	ToneMap  ToneMap
+	SRGB     bool
+}
+
+func (p PostProcess) Color(c *Color) *Color {
+	scale := math.Exp2(p.Exposure)
+	rgb := [3]float64{c.R() * scale, c.G() * scale, c.B() * scale}
+	for i, v := range rgb {
+		v = p.ToneMap.Channel(v)
+		if p.SRGB {
+			v = SRGBEncode(v)
+		}
+		rgb[i] = v
+	}
+	return InitColor(rgb[0], rgb[1], rgb[2])
+}
+
+// Apply returns a post processed copy of the canvas.
+func (p PostProcess) Apply(c *Canvas) *Canvas {
+	res := InitCanvas(c.Width, c.Height)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			res.SetPixel(p.Color(c.Pixel(x, y)), x, y)
+		}
+	}
+	return &res
+}
@@ -0,0 +1,100 @@
+package viz
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/maths"
+)
+
+func TestToneMapOperators(t *testing.T) {
+	type opt struct {
+		t   ToneMap
+		x   float64
+		exp float64
+	}
+	opts := []opt{
+		{ToneMapClamp, -1, 0},
+		{ToneMapClamp, 0.5, 0.5},
+		{ToneMapClamp, 3, 1},
+		{ToneMapReinhard, 0, 0},
+		{ToneMapReinhard, 1, 0.5},
+		{ToneMapReinhard, 3, 0.75},
+		{ToneMapACES, 0, 0},
+		{ToneMapACES, 0.18, 0.26690},
+		{ToneMapACES, 1, 0.80380},
+		{ToneMapACES, 100, 1},
+	}
+	for _, o := range opts {
+		assert.True(t, maths.FuzzyEquals(o.exp, o.t.Channel(o.x)), "%s(%v) = %v", o.t, o.x, o.t.Channel(o.x))
+	}
+}
+
+func TestParseToneMap(t *testing.T) {
+	for _, s := range []string{"clamp", "reinhard", "aces"} {
+		tm, err := ParseToneMap(s)
+		assert.Nil(t, err)
+		assert.Equal(t, ToneMap(s), tm)
+	}
+	tm, err := ParseToneMap("")
+	assert.Nil(t, err)
+	assert.Equal(t, ToneMapClamp, tm)
+	_, err = ParseToneMap("filmic")
+	assert.ErrorContains(t, err, "unknown tone map")
+}
+
+func TestSRGBTransferCurve(t *testing.T) {
+	type opt struct {
+		linear  float64
+		encoded float64
+	}
+	opts := []opt{
+		{0, 0},
+		{0.001, 0.01292},
+		{0.18, 0.46135},
+		{0.5, 0.73536},
+		{1, 1},
+	}
+	for _, o := range opts {
+		assert.True(t, maths.FuzzyEquals(o.encoded, SRGBEncode(o.linear)), "encode %v", o.linear)
+		assert.True(t, maths.FuzzyEquals(o.linear, SRGBDecode(o.encoded)), "decode %v", o.encoded)
+	}
+}
+
+func TestTheZeroPostProcessOnlyClamps(t *testing.T) {
+	p := PostProcess{}
+	assert.True(t, InitColor(0, 0.5, 1).Equals(p.Color(InitColor(-1, 0.5, 2))))
+}
+
+func TestPostProcessAppliesExposureBeforeToneMapping(t *testing.T) {
+	p := PostProcess{Exposure: 1, ToneMap: ToneMapReinhard, SRGB: true}
+	c := p.Color(InitColor(0.5, 1.5, 0))
+	assert.True(t, InitColor(SRGBEncode(0.5), SRGBEncode(0.75), 0).Equals(c))
+}
+
+func TestPostProcessApplyCopiesTheCanvas(t *testing.T) {
+	c := InitCanvas(2, 1)
+	c.SetPixel(InitColor(3, 3, 3), 1, 0)
+	res := PostProcess{ToneMap: ToneMapReinhard}.Apply(&c)
+	assert.True(t, InitColor(0.75, 0.75, 0.75).Equals(res.Pixel(1, 0)))
+	assert.True(t, InitColor(3, 3, 3).Equals(c.Pixel(1, 0)))
+}
+
+func TestEncodeCanvasWithLeavesHDRFormatsLinear(t *testing.T) {
+	c := InitCanvas(1, 1)
+	c.SetPixel(InitColor(4, 4, 4), 0, 0)
+	p := PostProcess{ToneMap: ToneMapReinhard}
+
+	buf := bytes.Buffer{}
+	assert.Nil(t, EncodeCanvasWith(&buf, &c, FormatPFM, p))
+	c2, err := ReadPFM(&buf)
+	assert.Nil(t, err)
+	assert.True(t, InitColor(4, 4, 4).Equals(c2.Pixel(0, 0)))
+
+	buf.Reset()
+	assert.Nil(t, EncodeCanvasWith(&buf, &c, FormatPPM, p))
+	c2, err = ReadPPM(&buf)
+	assert.Nil(t, err)
+	assert.True(t, InitColor(204.0/255, 204.0/255, 204.0/255).Equals(c2.Pixel(0, 0)))
+}
@@ -1,7 +1,9 @@
 package world
 
 import (
+	"context"
 	"math"
+	"runtime"
 	"sync"
 
 	"happymonday.dev/ray-tracer/src/matrix"
@@ -69,23 +71,85 @@ func (c *Camera) RayForPixel(px, py int) *shapes.Ray {
 	return shapes.InitRay(origin, direction)
 }
 
+// TileSize is the width and height in pixels of the squares an image is split
+// into for rendering.
+const TileSize = 16
+
+type Tile struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// RenderProgress is told about each tile as it finishes along with how many
+// of the total tiles are done. Calls are never concurrent.
+type RenderProgress func(t Tile, done, total int)
+
+func (c *Camera) Tiles() []Tile {
+	ts := []Tile{}
+	for y := 0; y < c.VSize; y += TileSize {
+		for x := 0; x < c.HSize; x += TileSize {
+			ts = append(ts, Tile{
+				X:      x,
+				Y:      y,
+				Width:  int(math.Min(TileSize, float64(c.HSize-x))),
+				Height: int(math.Min(TileSize, float64(c.VSize-y))),
+			})
+		}
+	}
+	return ts
+}
+
 func (c *Camera) Render(w *World) *viz.Canvas {
+	image, _ := c.RenderContext(context.Background(), w, nil)
+	return image
+}
+
+// RenderContext renders the image tile by tile on a pool of GOMAXPROCS
+// workers. It stops early with the context's error if ctx is cancelled.
+func (c *Camera) RenderContext(ctx context.Context, w *World, progress RenderProgress) (*viz.Canvas, error) {
 	image := viz.InitCanvas(c.HSize, c.VSize)
+	tiles := c.Tiles()
+	queue := make(chan Tile, len(tiles))
+	for _, t := range tiles {
+		queue <- t
+	}
+	close(queue)
+
+	progressLock := sync.Mutex{}
+	done := 0
 	wg := sync.WaitGroup{}
-	wg.Add(c.HSize * c.VSize)
+	workers := runtime.GOMAXPROCS(0)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for t := range queue {
+				if ctx.Err() != nil {
+					return
+				}
+				c.renderTile(w, &image, t)
+				progressLock.Lock()
+				done++
+				if progress != nil {
+					progress(t, done, len(tiles))
+				}
+				progressLock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
 
-	for iy := 0; iy < c.VSize; iy++ {
-		for ix := 0; ix < c.HSize; ix++ {
-			y := iy
-			x := ix
-			go func() {
-				r := c.RayForPixel(x, y)
-				color := w.ColorAt(r)
-				image.SetPixel(color, x, y)
-				wg.Done()
-			}()
+func (c *Camera) renderTile(w *World, image *viz.Canvas, t Tile) {
+	for y := t.Y; y < t.Y+t.Height; y++ {
+		for x := t.X; x < t.X+t.Width; x++ {
+			image.SetPixel(w.ColorAt(c.RayForPixel(x, y)), x, y)
 		}
 	}
-	wg.Wait()
-	return &image
 }
@@ -1,12 +1,16 @@
 package world
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/lights"
 	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/shapes"
 	"happymonday.dev/ray-tracer/src/tuples"
 	"happymonday.dev/ray-tracer/src/viz"
 )
@@ -64,3 +68,95 @@ func TestRenderingAWorldWithACamera(t *testing.T) {
 	image := c.Render(w)
 	assert.True(t, viz.InitColor(0.38066, 0.47583, 0.2855).Equals(image.Pixel(5, 5)))
 }
+
+func TestRepeatedRendersDoNotGrowMemory(t *testing.T) {
+	w := InitDefaultWorld()
+	c := InitCamera(50, 50, math.Pi/2.0)
+	c.SetTransform(ViewTransformation(tuples.InitPoint(0, 0, -5), tuples.InitPoint(0, 0, 0), tuples.InitVector(0, 1, 0)))
+	heap := func() uint64 {
+		var m runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&m)
+		return m.HeapAlloc
+	}
+
+	c.Render(w)
+	before := heap()
+	for i := 0; i < 20; i++ {
+		c.Render(w)
+	}
+	after := heap()
+	// a single 50x50 canvas is well under this, leaking intersections per ray
+	// across 20 renders is not
+	assert.Less(t, int64(after)-int64(before), int64(1<<20))
+}
+
+// BenchmarkRender renders a grid of small spheres at increasing object counts
+// to show how render time scales with scene size.
+func BenchmarkRender(b *testing.B) {
+	for _, n := range []int{10, 100, 1000, 5000} {
+		w := InitWorld()
+		w.Lights = []lights.Light{lights.InitPointLight(tuples.InitPoint(-10, 10, -10), viz.InitColor(1, 1, 1))}
+		side := int(math.Ceil(math.Sqrt(float64(n))))
+		for i := 0; i < n; i++ {
+			s := shapes.InitSphere()
+			x := float64(i%side)/float64(side)*4 - 2
+			y := float64(i/side)/float64(side)*4 - 2
+			s.SetTransform(matrix.Chain(matrix.Scaling(0.02, 0.02, 0.02), matrix.Translation(x, y, 0)))
+			w.Objects = append(w.Objects, s)
+		}
+		c := InitCamera(50, 50, math.Pi/2.0)
+		c.SetTransform(ViewTransformation(tuples.InitPoint(0, 0, -3), tuples.InitPoint(0, 0, 0), tuples.InitVector(0, 1, 0)))
+		b.Run(fmt.Sprintf("objects=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c.Render(w)
+			}
+		})
+	}
+}
+
+func TestTilesCoverTheCanvas(t *testing.T) {
+	c := InitCamera(40, 20, math.Pi/2.0)
+	covered := map[[2]int]int{}
+	for _, tile := range c.Tiles() {
+		for y := tile.Y; y < tile.Y+tile.Height; y++ {
+			for x := tile.X; x < tile.X+tile.Width; x++ {
+				covered[[2]int{x, y}]++
+			}
+		}
+	}
+	assert.Equal(t, 40*20, len(covered))
+	for _, n := range covered {
+		assert.Equal(t, 1, n)
+	}
+}
+
+func TestRenderReportsProgressPerTile(t *testing.T) {
+	w := InitDefaultWorld()
+	c := InitCamera(40, 20, math.Pi/2.0)
+	calls := 0
+	last := 0
+	image, err := c.RenderContext(context.Background(), w, func(tile Tile, done, total int) {
+		calls++
+		last = done
+		assert.Equal(t, len(c.Tiles()), total)
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, image)
+	assert.Equal(t, len(c.Tiles()), calls)
+	assert.Equal(t, len(c.Tiles()), last)
+}
+
+func TestRenderStopsWhenTheContextIsCancelled(t *testing.T) {
+	w := InitDefaultWorld()
+	c := InitCamera(200, 200, math.Pi/2.0)
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	image, err := c.RenderContext(ctx, w, func(tile Tile, done, total int) {
+		calls++
+		cancel()
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, image)
+	assert.Less(t, calls, len(c.Tiles()))
+}
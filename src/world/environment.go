@@ -0,0 +1,89 @@
+package world
+
+import (
+	"math"
+
+	"happymonday.dev/ray-tracer/src/maths"
+	"happymonday.dev/ray-tracer/src/shapes"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+// background is the color seen by a ray that hits nothing.
+func (w *World) background(r *shapes.Ray) *viz.Color {
+	if w.Environment == nil {
+		return viz.Black()
+	}
+	return w.Environment.ColorAt(r.Direction)
+}
+
+// jitter is the source of random numbers for sampling around p.
+func (w *World) jitter(p *tuples.Tuple) func() float64 {
+	if w.Jitter == nil {
+		return maths.PointJitter(p.X, p.Y, p.Z)
+	}
+	return w.Jitter
+}
+
+// EnvironmentLight is the diffuse and glossy light the environment casts on
+// the hit. Directions are importance sampled, cosine weighted around the
+// normal for diffuse and from the Phong lobe around the reflection for
+// glossy, so each sample carries an equal weight.
+func (w *World) EnvironmentLight(c *shapes.IntersectionComputations) *viz.Color {
+	if w.Environment == nil || w.EnvironmentSamples <= 0 {
+		return viz.Black()
+	}
+	m := c.Object.Material()
+	jitter := w.jitter(c.OverPoint)
+	diffuse := viz.Black()
+	specular := viz.Black()
+	for i := 0; i < w.EnvironmentSamples; i++ {
+		if m.Diffuse > 0 {
+			d := sampleHemisphere(c.NormalV, 1, jitter(), jitter())
+			diffuse = diffuse.Add(w.environmentAlong(c.OverPoint, d))
+		}
+		if m.Specular > 0 {
+			d := sampleHemisphere(c.ReflectV, m.Shininess, jitter(), jitter())
+			cos := d.DotProduct(c.NormalV)
+			if cos > 0 {
+				// The lobe's pdf leaves the cosine and the normalisation of
+				// the Phong lobe to weight each sample by.
+				weight := cos * (m.Shininess + 2) / (m.Shininess + 1)
+				specular = specular.Add(w.environmentAlong(c.OverPoint, d).MultiplyScalar(weight))
+			}
+		}
+	}
+	n := float64(w.EnvironmentSamples)
+	diffuse = diffuse.Multiply(m.ColorAt(c.Object, c.Point)).MultiplyScalar(m.Diffuse / n)
+	return diffuse.Add(specular.MultiplyScalar(m.Specular / n))
+}
+
+// environmentAlong is the environment seen from p in direction d, or black
+// when something in the scene is in the way.
+func (w *World) environmentAlong(p, d *tuples.Tuple) *viz.Color {
+	if w.IsShadowed(d, p) {
+		return viz.Black()
+	}
+	return w.Environment.ColorAt(d)
+}
+
+// sampleHemisphere picks a direction around axis from two uniform random
+// numbers, with a density proportional to cos^exponent of the angle from the
+// axis. An exponent of 1 gives cosine weighted directions.
+func sampleHemisphere(axis *tuples.Tuple, exponent, r1, r2 float64) *tuples.Tuple {
+	phi := 2 * math.Pi * r1
+	cosTheta := math.Pow(1-r2, 1/(exponent+1))
+	sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+
+	// Any vector not parallel to the axis gives a basis around it.
+	a := tuples.InitVector(1, 0, 0)
+	if math.Abs(axis.X) > 0.9 {
+		a = tuples.InitVector(0, 1, 0)
+	}
+	t := a.CrossProduct(axis).Normalize()
+	b := axis.CrossProduct(t)
+	return t.MultiplyScalar(math.Cos(phi) * sinTheta).
+		Add(b.MultiplyScalar(math.Sin(phi) * sinTheta)).
+		Add(axis.MultiplyScalar(cosTheta)).
+		Normalize()
+}
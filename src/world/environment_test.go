@@ -0,0 +1,151 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"happymonday.dev/ray-tracer/src/environment"
+	"happymonday.dev/ray-tracer/src/lights"
+	"happymonday.dev/ray-tracer/src/matrix"
+	"happymonday.dev/ray-tracer/src/shapes"
+	"happymonday.dev/ray-tracer/src/tuples"
+	"happymonday.dev/ray-tracer/src/viz"
+)
+
+func TestRaysThatMissSeeTheEnvironment(t *testing.T) {
+	w := InitDefaultWorld()
+	r := shapes.InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 1, 0))
+	assert.True(t, viz.Black().Equals(w.ColorAt(r)))
+
+	w.Environment = environment.InitGradientSky(viz.InitColor(0, 0, 1), viz.InitColor(1, 1, 1), viz.InitColor(0, 1, 0))
+	assert.True(t, viz.InitColor(0, 0, 1).Equals(w.ColorAt(r)))
+}
+
+// envWorld has a single sphere lit only by a uniform white environment.
+func envWorld() (*World, *shapes.Sphere) {
+	w := InitWorld()
+	s := shapes.InitSphere()
+	s.Material().Color = viz.InitColor(1, 0.5, 0.25)
+	s.Material().Ambient = 0
+	s.Material().Diffuse = 0.9
+	s.Material().Specular = 0
+	w.Objects = []shapes.Shape{s}
+	w.Environment = environment.InitUniform(viz.InitColor(1, 1, 1))
+	w.EnvironmentSamples = 16
+	return w, s
+}
+
+func TestTheEnvironmentOnlyLightsWithSamples(t *testing.T) {
+	w, _ := envWorld()
+	w.EnvironmentSamples = 0
+	r := shapes.InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1))
+	assert.True(t, viz.Black().Equals(w.ColorAt(r)))
+}
+
+func TestAnUnoccludedDiffuseSurfaceSeesTheWholeEnvironment(t *testing.T) {
+	w, _ := envWorld()
+	// Importance sampling means every sample of a uniform environment has
+	// the same weight, so the result is exact.
+	for _, d := range []*tuples.Tuple{tuples.InitVector(0, 0, 1), tuples.InitVector(0.1, -0.05, 1).Normalize()} {
+		r := shapes.InitRay(tuples.InitPoint(0, 0, -5), d)
+		c := w.ColorAt(r)
+		assert.True(t, viz.InitColor(0.9, 0.45, 0.225).Equals(c), "%v", c)
+	}
+}
+
+func TestObjectsBlockEnvironmentLight(t *testing.T) {
+	w, s := envWorld()
+	s.SetTransform(matrix.Translation(0, 2, 0))
+	floor := shapes.InitPlane()
+	floor.Material().Ambient = 0
+	floor.Material().Specular = 0
+	floor.Material().Diffuse = 1
+	w.Objects = append(w.Objects, floor)
+	w.EnvironmentSamples = 200
+
+	// Far from the sphere it sits near the horizon and blocks about 0.025% of
+	// the cosine weighted sky, so an unlucky sample or two may still hit it.
+	open := w.ColorAt(shapes.InitRay(tuples.InitPoint(20, 1, 0), tuples.InitVector(0, -1, 0)))
+	assert.InDelta(t, 1, open.R(), 0.01, "%v", open)
+	// Seen from the point below it the sphere spans 30 degrees from the
+	// normal, which blocks sin^2(30) = a quarter of the cosine weighted sky.
+	under := w.ColorAt(shapes.InitRay(tuples.InitPoint(0, 0.5, -3), tuples.InitVector(0, -0.5, 3).Normalize()))
+	assert.InDelta(t, 0.75, under.R(), 0.05, "%v", under)
+}
+
+func TestGlossyEnvironmentReflections(t *testing.T) {
+	w, s := envWorld()
+	s.Material().Diffuse = 0
+	s.Material().Specular = 1
+	s.Material().Shininess = 200
+	w.EnvironmentSamples = 200
+	r := shapes.InitRay(tuples.InitPoint(0, 0, -5), tuples.InitVector(0, 0, 1))
+	c := w.ColorAt(r)
+	assert.InDelta(t, 1, c.R(), 0.02, "%v", c)
+	assert.True(t, c.R() == c.G() && c.G() == c.B())
+}
+
+func TestEnvironmentSamplingRepeatsAcrossConcurrentRenders(t *testing.T) {
+	w, _ := envWorld()
+	w.Environment = environment.InitGradientSky(viz.InitColor(0, 0, 1), viz.InitColor(1, 1, 1), viz.InitColor(0, 1, 0))
+	w.EnvironmentSamples = 4
+	c := InitCamera(24, 24, math.Pi/3)
+	c.SetTransform(ViewTransformation(tuples.InitPoint(0, 0, -5), tuples.InitPoint(0, 0, 0), tuples.InitVector(0, 1, 0)))
+	a := c.Render(w)
+	b := c.Render(w)
+	for y := 0; y < c.VSize; y++ {
+		for x := 0; x < c.HSize; x++ {
+			assert.Equal(t, a.Pixel(x, y), b.Pixel(x, y), "pixel (%d, %d)", x, y)
+		}
+	}
+}
+
+func TestSampleHemisphere(t *testing.T) {
+	type opt struct {
+		exponent float64
+		meanCos  float64
+	}
+	// For a density of cos^n the mean cosine is (n+1)/(n+2).
+	opts := []opt{
+		{1, 2.0 / 3},
+		{10, 11.0 / 12},
+	}
+	axis := tuples.InitVector(1, 1, 0).Normalize()
+	rnd := rand.New(rand.NewSource(1))
+	for _, o := range opts {
+		sum := 0.0
+		n := 20000
+		for i := 0; i < n; i++ {
+			d := sampleHemisphere(axis, o.exponent, rnd.Float64(), rnd.Float64())
+			assert.InDelta(t, 1, d.Magnitude(), 1e-9)
+			cos := d.DotProduct(axis)
+			assert.GreaterOrEqual(t, cos, 0.0)
+			sum += cos
+		}
+		assert.InDelta(t, o.meanCos, sum/float64(n), 0.01, "exponent %v", o.exponent)
+	}
+	// Axes along x use a different helper vector for the basis.
+	d := sampleHemisphere(tuples.InitVector(1, 0, 0), 1, 0.25, 0)
+	assert.True(t, math.Abs(d.X-1) < 1e-9, "%v", d)
+}
+
+func TestADaylightSkyAndItsSun(t *testing.T) {
+	w := InitDefaultWorld()
+	sky := environment.InitPreethamSky(tuples.InitVector(1, 1, -1), 3)
+	w.Environment = sky
+	w.Lights = []lights.Light{sky.Sun()}
+
+	up := tuples.InitVector(0, 1, 0)
+	assert.True(t, sky.ColorAt(up).Equals(w.ColorAt(shapes.InitRay(tuples.InitPoint(0, 0, -5), up))))
+
+	// The side of the sphere facing the sun is lit, the far side only gets
+	// the ambient share of the sunlight.
+	r := shapes.InitRay(tuples.InitPoint(5, 5, -5), tuples.InitVector(-1, -1, 1).Normalize())
+	lit := w.ColorAt(r)
+	r = shapes.InitRay(tuples.InitPoint(-5, -5, 5), tuples.InitVector(1, 1, -1).Normalize())
+	dark := w.ColorAt(r)
+	assert.Greater(t, lit.G(), dark.G())
+	assert.True(t, viz.InitColor(0.08, 0.1, 0.06).Multiply(sky.SunColor()).Equals(dark), "%v", dark)
+}
@@ -1,8 +1,9 @@
 package world
 
 import (
-	"sync"
+	"math"
 
+	"happymonday.dev/ray-tracer/src/environment"
 	"happymonday.dev/ray-tracer/src/lights"
 	"happymonday.dev/ray-tracer/src/matrix"
 	"happymonday.dev/ray-tracer/src/shapes"
@@ -10,13 +11,33 @@ import (
 	"happymonday.dev/ray-tracer/src/viz"
 )
 
+// DefaultMaxDepth is how many times a ray may bounce off reflective or through
+// transparent surfaces when a world doesn't set MaxDepth.
+const DefaultMaxDepth = 5
+
 type World struct {
 	Objects []shapes.Shape
-	Lights  []*lights.PointLight
+	Lights  []lights.Light
+	// MaxDepth limits how many reflection and refraction rays are spawned
+	// from a single camera ray, zero means DefaultMaxDepth and a negative
+	// value disables both
+	MaxDepth int
+	// Environment colors rays that miss every object, black when nil. With
+	// EnvironmentSamples above zero it also lights surfaces, taking that
+	// many diffuse and glossy samples per hit.
+	Environment        environment.Environment
+	EnvironmentSamples int
+	// Jitter returns random numbers from 0 to 1 for sampling the
+	// environment. When nil they are seeded from each hit point so renders
+	// repeat exactly. Renders shade from several goroutines at once, so a
+	// Jitter must be safe for concurrent use, which a *rand.Rand isn't.
+	Jitter func() float64
+
+	bvh shapes.BVHCache
 }
 
 func InitWorld() *World {
-	return &World{}
+	return &World{MaxDepth: DefaultMaxDepth}
 }
 
 func InitDefaultWorld() *World {
@@ -27,53 +48,109 @@ func InitDefaultWorld() *World {
 	s1.Material().Specular = 0.2
 	s2 := shapes.InitSphere()
 	s2.SetTransform(matrix.Scaling(0.5, 0.5, 0.5))
-	return &World{[]shapes.Shape{s1, s2}, []*lights.PointLight{l}}
+	return &World{Objects: []shapes.Shape{s1, s2}, Lights: []lights.Light{l}, MaxDepth: DefaultMaxDepth}
+}
+
+// Rebuild rebuilds the BVH used to cull objects in Intersections. It happens
+// automatically when Objects is reassigned or resized, when an object's
+// transform is set, or when a group's children change. Editing fields like a
+// cylinder's Maximum needs the object's Invalidate, and replacing an element
+// of Objects in place needs a Rebuild, before the next render.
+func (w *World) Rebuild() *shapes.BVH {
+	return w.bvh.Rebuild(w.Objects)
 }
 
 func (w *World) Intersections(r *shapes.Ray) *shapes.Intersections {
-	ress := make(chan []*shapes.Intersection)
-	wg := sync.WaitGroup{}
-	wg.Add(len(w.Objects))
-	for _, o := range w.Objects {
-		obj := o
-		go func() {
-			ress <- obj.Intersect(r).Intersections
-			wg.Done()
-		}()
-	}
-	go func() {
-		wg.Wait()
-		close(ress)
-	}()
-	xs := []*shapes.Intersection{}
-	for res := range ress {
-		xs = append(xs, res...)
-	}
-	return shapes.InitIntersections(xs...)
+	return w.bvh.Get(w.Objects).Intersect(r)
 }
 
 func (w *World) ShadeHit(c *shapes.IntersectionComputations) *viz.Color {
+	return w.ShadeHitDepth(c, w.maxDepth())
+}
+
+func (w *World) maxDepth() int {
+	if w.MaxDepth == 0 {
+		return DefaultMaxDepth
+	}
+	return w.MaxDepth
+}
+
+// ShadeHitDepth shades the hit allowing remaining more reflection or
+// refraction bounces.
+func (w *World) ShadeHitDepth(c *shapes.IntersectionComputations, remaining int) *viz.Color {
 	res := viz.Black()
 	for _, l := range w.Lights {
-		res = res.Add(l.Lighting(c.Object.Material(), c.Point, c.EyeV, c.NormalV, w.IsShadowed(l, c.OverPoint)))
+		intensity := l.IntensityAt(c.OverPoint, w)
+		res = res.Add(l.Lighting(c.Object.Material(), c.Object, c.Point, c.EyeV, c.NormalV, intensity))
+	}
+	res = res.Add(w.EnvironmentLight(c))
+	reflected := w.ReflectedColor(c, remaining)
+	refracted := w.RefractedColor(c, remaining)
+	m := c.Object.Material()
+	if m.Reflective > 0 && m.Transparency > 0 {
+		reflectance := c.Schlick()
+		return res.Add(reflected.MultiplyScalar(reflectance)).Add(refracted.MultiplyScalar(1 - reflectance))
 	}
-	return res
+	return res.Add(reflected).Add(refracted)
 }
 
 func (w *World) ColorAt(r *shapes.Ray) *viz.Color {
+	return w.ColorAtDepth(r, w.maxDepth())
+}
+
+func (w *World) ColorAtDepth(r *shapes.Ray, remaining int) *viz.Color {
 	is := w.Intersections(r)
 	h := is.Hit()
 	if h == nil {
+		return w.background(r)
+	}
+	return w.ShadeHitDepth(h.PrepareComputations(r, is), remaining)
+}
+
+// ReflectedColor is the color seen in the reflection at the hit, scaled by
+// how reflective the material is.
+func (w *World) ReflectedColor(c *shapes.IntersectionComputations, remaining int) *viz.Color {
+	reflective := c.Object.Material().Reflective
+	if remaining <= 0 || reflective == 0 {
 		return viz.Black()
 	}
-	return w.ShadeHit(h.PrepareComputations(r))
+	r := shapes.InitRay(c.OverPoint, c.ReflectV)
+	return w.ColorAtDepth(r, remaining-1).MultiplyScalar(reflective)
 }
 
-func (w *World) IsShadowed(l *lights.PointLight, p *tuples.Tuple) bool {
-	v := l.Position.Subtract(p)
-	distance := v.Magnitude()
-	direction := v.Normalize()
+// IsShadowed reports whether anything lies between p and a point on a light.
+// A vector light position is the direction towards a light at infinity, so
+// anything in that direction casts a shadow.
+func (w *World) IsShadowed(lightPosition, p *tuples.Tuple) bool {
+	distance := math.Inf(1)
+	direction := lightPosition.Normalize()
+	if lightPosition.W != 0 {
+		v := lightPosition.Subtract(p)
+		distance = v.Magnitude()
+		direction = v.Normalize()
+	}
 	r := shapes.InitRay(p, direction)
 	h := w.Intersections(r).Hit()
 	return h != nil && h.T < distance
 }
+
+// RefractedColor is the color seen through the hit, scaled by how transparent
+// the material is. Rays past the critical angle are totally internally
+// reflected and contribute nothing here.
+func (w *World) RefractedColor(c *shapes.IntersectionComputations, remaining int) *viz.Color {
+	transparency := c.Object.Material().Transparency
+	if remaining <= 0 || transparency == 0 {
+		return viz.Black()
+	}
+	// Snell's law, sin(theta_t) from the ratio of indices and cos(theta_i)
+	nRatio := c.N1 / c.N2
+	cosI := c.EyeV.DotProduct(c.NormalV)
+	sin2T := math.Pow(nRatio, 2) * (1 - math.Pow(cosI, 2))
+	if sin2T > 1 {
+		return viz.Black()
+	}
+	cosT := math.Sqrt(1 - sin2T)
+	direction := c.NormalV.MultiplyScalar(nRatio*cosI - cosT).Subtract(c.EyeV.MultiplyScalar(nRatio))
+	r := shapes.InitRay(c.UnderPoint, direction)
+	return w.ColorAtDepth(r, remaining-1).MultiplyScalar(transparency)
+}